@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/715d/unusedfunc/pkg/suppress"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// auditStyles lists the suppression styles in the order they are reported.
+var auditStyles = []string{
+	suppress.SuppressionNolint.String(),
+	suppress.SuppressionLintIgnore.String(),
+	suppress.SuppressionIgnore.String(),
+	suppress.SuppressionFileIgnore.String(),
+	suppress.SuppressionGenericNolint.String(),
+}
+
+// runSuppressionAudit loads the packages without analyzing them and writes
+// a tally of the suppression comment styles in use to w.
+func runSuppressionAudit(ctx context.Context, cfg *Config, w io.Writer) error {
+	pkgs, err := unusedfunc.LoadPackages(ctx, unusedfunc.LoaderOptions{
+		Packages:  cfg.Packages,
+		BuildTags: cfg.BuildTags,
+		NoTests:   !cfg.Tests,
+	})
+	if err != nil {
+		return fmt.Errorf("loading packages: %w", err)
+	}
+	comments, err := unusedfunc.AuditSuppressions(pkgs)
+	if err != nil {
+		return err
+	}
+
+	byStyle := make(map[string][]unusedfunc.SuppressionComment)
+	for _, c := range comments {
+		byStyle[c.Style] = append(byStyle[c.Style], c)
+	}
+
+	if cfg.Format == "json" {
+		counts := make(map[string]int, len(auditStyles))
+		for _, style := range auditStyles {
+			counts[style] = len(byStyle[style])
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Counts   map[string]int                  `json:"counts"`
+			Comments []unusedfunc.SuppressionComment `json:"comments"`
+		}{counts, comments})
+	}
+
+	for _, style := range auditStyles {
+		if _, err := fmt.Fprintf(w, "%s: %d\n", style, len(byStyle[style])); err != nil {
+			return err
+		}
+		for _, c := range byStyle[style] {
+			line := fmt.Sprintf("  %s", c.Position)
+			if c.Reason != "" {
+				line += " (" + c.Reason + ")"
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// Formats of --baseline-format.
+const (
+	baselineJSON = "json"
+	baselineText = "txt"
+)
+
+// baselineEntry is a finding as recorded in a JSON baseline. Positions are
+// left out so that edits moving code around do not change the file; the
+// fields read by --compare-with are enough.
+type baselineEntry struct {
+	Name    string `json:"name"`
+	Package string `json:"package"`
+	Reason  string `json:"reason"`
+}
+
+// writeBaseline writes the unsuppressed findings to the file path, one per
+// function, sorted by package, then receiver, then name, so that
+// regenerating the file changes only the lines of the findings that came or
+// went. The json format can be read back with --compare-with, as can the
+// txt format, which has one name per line for editing by hand.
+func writeBaseline(path, format string, findings []unusedfunc.UnusedFunction) error {
+	var entries []unusedfunc.UnusedFunction
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		if f.Suppressed || seen[f.Name] {
+			continue
+		}
+		seen[f.Name] = true
+		entries = append(entries, f)
+	}
+	slices.SortFunc(entries, func(a, b unusedfunc.UnusedFunction) int {
+		if cmp := strings.Compare(a.Package, b.Package); cmp != 0 {
+			return cmp
+		}
+		if cmp := strings.Compare(a.Receiver, b.Receiver); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var buf bytes.Buffer
+	switch format {
+	case baselineText:
+		for _, f := range entries {
+			fmt.Fprintln(&buf, f.Name)
+		}
+	default:
+		baseline := struct {
+			FormatVersion   int             `json:"format_version"`
+			UnusedFunctions []baselineEntry `json:"unused_functions"`
+		}{
+			FormatVersion:   report.JSONFormatVersion,
+			UnusedFunctions: make([]baselineEntry, 0, len(entries)),
+		}
+		for _, f := range entries {
+			baseline.UnusedFunctions = append(baseline.UnusedFunctions, baselineEntry{
+				Name:    f.Name,
+				Package: f.Package,
+				Reason:  f.Reason,
+			})
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(baseline); err != nil {
+			return fmt.Errorf("encode baseline: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	return nil
+}
+
+// readTextBaseline returns the findings named in a txt baseline, skipping
+// blank lines and lines starting with '#'.
+func readTextBaseline(data []byte) []unusedfunc.UnusedFunction {
+	var functions []unusedfunc.UnusedFunction
+	for line := range strings.Lines(string(data)) {
+		name := strings.TrimSpace(line)
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		functions = append(functions, unusedfunc.UnusedFunction{Name: name})
+	}
+	return functions
+}
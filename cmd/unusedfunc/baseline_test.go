@@ -0,0 +1,130 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+func TestWriteBaseline(t *testing.T) {
+	findings := []unusedfunc.UnusedFunction{
+		{Name: "example.com/b.f", Package: "example.com/b", Reason: unusedfunc.UnexportedReason},
+		{Name: "example.com/a.*T.z", Package: "example.com/a", Receiver: "example.com/a.T", Reason: unusedfunc.UnexportedReason},
+		{Name: "example.com/a.g", Package: "example.com/a", Reason: unusedfunc.UnexportedReason},
+		{Name: "example.com/a.S.m", Package: "example.com/a", Receiver: "example.com/a.S", Reason: unusedfunc.UnexportedReason},
+		{Name: "example.com/a.*T.b", Package: "example.com/a", Receiver: "example.com/a.T", Reason: unusedfunc.InternalReason},
+		{Name: "example.com/a.g", Package: "example.com/a", Reason: unusedfunc.UnexportedReason},
+		{Name: "example.com/a.ignored", Package: "example.com/a", Suppressed: true},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "text",
+			format: baselineText,
+			want: `example.com/a.g
+example.com/a.S.m
+example.com/a.*T.b
+example.com/a.*T.z
+example.com/b.f
+`,
+		},
+		{
+			name:   "json",
+			format: baselineJSON,
+			want: `{
+  "format_version": 1,
+  "unused_functions": [
+    {
+      "name": "example.com/a.g",
+      "package": "example.com/a",
+      "reason": "unexported and unused"
+    },
+    {
+      "name": "example.com/a.S.m",
+      "package": "example.com/a",
+      "reason": "unexported and unused"
+    },
+    {
+      "name": "example.com/a.*T.b",
+      "package": "example.com/a",
+      "reason": "exported in internal and unused"
+    },
+    {
+      "name": "example.com/a.*T.z",
+      "package": "example.com/a",
+      "reason": "unexported and unused"
+    },
+    {
+      "name": "example.com/b.f",
+      "package": "example.com/b",
+      "reason": "unexported and unused"
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "baseline")
+			require.NoError(t, writeBaseline(path, tt.format, findings))
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+
+			// --compare-with reads the baseline back.
+			loaded, err := loadReport(path)
+			require.NoError(t, err)
+			var names []string
+			for _, f := range loaded {
+				names = append(names, f.Name)
+			}
+			require.Equal(t, []string{
+				"example.com/a.g",
+				"example.com/a.S.m",
+				"example.com/a.*T.b",
+				"example.com/a.*T.z",
+				"example.com/b.f",
+			}, names)
+		})
+	}
+}
+
+func TestReadTextBaseline(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{name: "empty", data: ""},
+		{
+			name: "names",
+			data: "example.com/a.g\nexample.com/a.*T.b\n",
+			want: []string{"example.com/a.g", "example.com/a.*T.b"},
+		},
+		{
+			name: "comments_blank_lines_and_spaces",
+			data: "# accepted for now\n\n  example.com/a.g  \r\n#example.com/a.h\nexample.com/b.f",
+			want: []string{"example.com/a.g", "example.com/b.f"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range readTextBaseline([]byte(tt.data)) {
+				got = append(got, f.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
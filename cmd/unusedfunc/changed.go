@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/715d/unusedfunc/pkg/report"
+)
+
+// dateLayouts are the accepted formats of --changed-after and --changed-before.
+var dateLayouts = []string{time.DateOnly, time.RFC3339}
+
+// parseDate parses a date given on the command line.
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: want YYYY-MM-DD or RFC 3339", s)
+}
+
+// changeDates resolves when files were last changed: the committer date of
+// the last commit touching the file, or its modification time when the file
+// is not tracked by git.
+type changeDates map[string]time.Time // filename -> last change (zero if unknown)
+
+// lastChange returns when filename was last changed, or false if unknown.
+func (c changeDates) lastChange(ctx context.Context, filename string) (time.Time, bool) {
+	if t, ok := c[filename]; ok {
+		return t, !t.IsZero()
+	}
+
+	t, err := gitDate(ctx, filename)
+	if err != nil || t.IsZero() {
+		slog.Debug("no git history, using modification time", "file", filename, "err", err)
+		if info, statErr := os.Stat(filename); statErr == nil {
+			t = info.ModTime()
+		}
+	}
+	c[filename] = t
+	return t, !t.IsZero()
+}
+
+// gitDate returns the committer date of the last commit touching filename.
+// A zero time is returned for untracked files.
+func gitDate(ctx context.Context, filename string) (time.Time, error) {
+	cmd := exec.CommandContext(ctx, "git", "log", "-1", "--format=%cI", "--", filepath.Base(filename))
+	cmd.Dir = filepath.Dir(filename)
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("git log: %w", err)
+	}
+	date := strings.TrimSpace(string(out))
+	if date == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, date)
+}
+
+// filterByChangeDate keeps only findings in files last changed after `after`
+// and before `before`. Zero bounds are ignored. Findings whose file date is
+// unknown are kept, since they cannot be placed in either window.
+func filterByChangeDate(ctx context.Context, result *report.Result, after, before time.Time) {
+	dates := make(changeDates)
+	kept := result.UnusedFunctions[:0]
+	for _, f := range result.UnusedFunctions {
+		changed, ok := dates.lastChange(ctx, f.Position.Filename)
+		if ok && ((!after.IsZero() && !changed.After(after)) || (!before.IsZero() && !changed.Before(before))) {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	result.UnusedFunctions = kept
+	result.Stats.UnusedFunctions = len(kept)
+}
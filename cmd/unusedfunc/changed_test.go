@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2024-03-01", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-03-01T12:30:00Z", want: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{input: "2024-03-01T12:30:00+02:00", want: time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)},
+		{input: "01/03/2024", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseDate(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestFilterByChangeDate(t *testing.T) {
+	dir := initGitRepo(t)
+	commitFile(t, dir, "old.go", "2020-01-15T00:00:00Z")
+	commitFile(t, dir, "new.go", "2024-06-15T00:00:00Z")
+	untracked := filepath.Join(dir, "untracked.go")
+	require.NoError(t, os.WriteFile(untracked, nil, 0o600))
+	mtime := time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(untracked, mtime, mtime))
+
+	date := func(s string) time.Time {
+		d, err := parseDate(s)
+		require.NoError(t, err)
+		return d
+	}
+
+	tests := []struct {
+		name   string
+		after  time.Time
+		before time.Time
+		want   []string
+	}{
+		{
+			name: "no_bounds",
+			want: []string{"old.go", "new.go", "untracked.go", "missing.go"},
+		},
+		{
+			name:  "after",
+			after: date("2021-01-01"),
+			want:  []string{"new.go", "untracked.go", "missing.go"},
+		},
+		{
+			name:   "before",
+			before: date("2021-01-01"),
+			want:   []string{"old.go", "missing.go"},
+		},
+		{
+			name:   "window_uses_modification_time_when_untracked",
+			after:  date("2021-01-01"),
+			before: date("2023-01-01"),
+			want:   []string{"untracked.go", "missing.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &report.Result{}
+			for _, name := range []string{"old.go", "new.go", "untracked.go", "missing.go"} {
+				result.UnusedFunctions = append(result.UnusedFunctions, unusedfunc.UnusedFunction{
+					Name:     "example.com/p." + name,
+					Position: token.Position{Filename: filepath.Join(dir, name), Line: 1},
+				})
+			}
+			result.Stats.UnusedFunctions = len(result.UnusedFunctions)
+
+			filterByChangeDate(context.Background(), result, tt.after, tt.before)
+
+			var got []string
+			for _, f := range result.UnusedFunctions {
+				got = append(got, filepath.Base(f.Position.Filename))
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, len(tt.want), result.Stats.UnusedFunctions)
+		})
+	}
+}
+
+// initGitRepo creates an empty git repository in a temporary directory and
+// returns its path, with symbolic links resolved as git reports them.
+func initGitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	runGit(t, dir, "", "init", "-q")
+	return dir
+}
+
+// commitFile writes name in the repository at dir, if it does not exist
+// yet, and commits it at date, an RFC 3339 timestamp.
+func commitFile(t *testing.T, dir, name, date string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+	}
+	runGit(t, dir, date, "add", "--", name)
+	runGit(t, dir, date, "commit", "-q", "-m", "add "+name)
+}
+
+// runGit runs git in dir with a fixed identity, authoring and committing at
+// date unless it is empty.
+func runGit(t *testing.T, dir, date string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_NOSYSTEM=1", "HOME="+dir,
+	)
+	if date != "" {
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	}
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+}
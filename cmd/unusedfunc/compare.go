@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// loadReport reads the findings of a previous run written with --json or
+// --write-baseline. A file not starting with '{' is a txt baseline.
+func loadReport(path string) ([]unusedfunc.UnusedFunction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read report: %w", err)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return readTextBaseline(data), nil
+	}
+
+	functions, err := report.ReadJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse report %s: %w", path, err)
+	}
+	return functions, nil
+}
+
+// compareWithReport narrows result to the findings that are not in the report
+// at path. Findings of the report that are gone are kept in result.Removed.
+func compareWithReport(result *report.Result, path string) error {
+	base, err := loadReport(path)
+	if err != nil {
+		return err
+	}
+	added, removed := unusedfunc.Diff(base, result.UnusedFunctions)
+	result.UnusedFunctions = added
+	result.Removed = removed
+	result.Stats.UnusedFunctions = len(added)
+	return nil
+}
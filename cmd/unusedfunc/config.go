@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/spf13/pflag"
+)
+
+// effectiveConfig returns the value of every flag, defaults included, keyed
+// by flag name so that it can be pasted back on the command line. Booleans
+// and integers keep their JSON types, lists are arrays, and everything else,
+// durations included, is the string the flag would accept, such as "30s".
+func effectiveConfig(flags *pflag.FlagSet, packages []string) map[string]any {
+	config := map[string]any{"packages": packages}
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Name == "help" {
+			return
+		}
+		switch f.Value.Type() {
+		case "bool":
+			v, err := strconv.ParseBool(f.Value.String())
+			if err == nil {
+				config[f.Name] = v
+				return
+			}
+		case "int":
+			v, err := strconv.Atoi(f.Value.String())
+			if err == nil {
+				config[f.Name] = v
+				return
+			}
+		case "stringSlice":
+			if v, ok := f.Value.(pflag.SliceValue); ok {
+				config[f.Name] = append([]string{}, v.GetSlice()...)
+				return
+			}
+		}
+		config[f.Name] = f.Value.String()
+	})
+	return config
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"cmp"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/715d/unusedfunc/internal/analysis"
+)
+
+// successorSuffix matches the suffixes that mark a new version of a
+// function, as in ParseV2, ParseNew or Parse2.
+var successorSuffix = regexp.MustCompile(`^(V[0-9]+|New|[0-9]+)$`)
+
+// successors maps the unused exported functions of funcs to the used
+// function likely superseding them: one of the same package, and receiver
+// for methods, named like it plus a successorSuffix. When there are several,
+// the latest version wins. It is a triage aid, based on names alone.
+func successors(funcs map[types.Object]*analysis.FuncInfo) map[types.Object]string {
+	type scope struct{ pkg, recv string }
+	scopeOf := func(f *analysis.FuncInfo) scope {
+		var s scope
+		if pkg := f.Object.Pkg(); pkg != nil {
+			s.pkg = pkg.Path()
+		}
+		if named := f.ReceiverType(); named != nil {
+			s.recv = named.Obj().Name()
+		}
+		return s
+	}
+
+	used := make(map[scope][]*analysis.FuncInfo)
+	for _, f := range funcs {
+		if f.IsUsed {
+			used[scopeOf(f)] = append(used[scopeOf(f)], f)
+		}
+	}
+
+	result := make(map[types.Object]string)
+	for obj, f := range funcs {
+		if !f.ShouldReport() || !f.IsExported {
+			continue
+		}
+		var best *analysis.FuncInfo
+		for _, g := range used[scopeOf(f)] {
+			suffix, ok := strings.CutPrefix(g.Object.Name(), obj.Name())
+			if !ok || !successorSuffix.MatchString(suffix) {
+				continue
+			}
+			if best == nil || newerName(g.Object.Name(), best.Object.Name()) {
+				best = g
+			}
+		}
+		if best != nil {
+			result[obj] = best.Name
+		}
+	}
+	return result
+}
+
+// newerName reports whether the version suffix of a is newer than that of b:
+// longer, as V10 is after V9, or else later in order.
+func newerName(a, b string) bool {
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c > 0
+	}
+	return a > b
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// limitDepth keeps only the findings at most maxDepth levels down their
+// cluster of dead functions (see cascadeDepths), so that a run lists the
+// functions to delete first. Deleting them reveals the next levels.
+func limitDepth(result *report.Result, maxDepth int) {
+	depths := cascadeDepths(result.UnusedFunctions)
+	kept := result.UnusedFunctions[:0]
+	for _, f := range result.UnusedFunctions {
+		if depths[f.Name] <= maxDepth {
+			kept = append(kept, f)
+		}
+	}
+	if hidden := len(result.UnusedFunctions) - len(kept); hidden > 0 {
+		slog.Info("findings below --max-depth not reported", "max_depth", maxDepth, "hidden", hidden)
+	}
+	result.UnusedFunctions = kept
+	result.Stats.UnusedFunctions = len(kept)
+}
+
+// cascadeDepths returns the depth of each finding in the call graph of the
+// findings: 1 for a function no other finding calls, the top of its
+// cluster, and one more than its shallowest caller otherwise. A cycle of
+// findings that no other finding calls has no top; its smallest name, by
+// string order, is taken as one.
+func cascadeDepths(findings []unusedfunc.UnusedFunction) map[string]int {
+	calls := make(map[string][]string, len(findings))
+	called := make(map[string]bool)
+	for _, f := range findings {
+		calls[f.Name] = append(calls[f.Name], f.Calls...)
+	}
+	for _, callees := range calls {
+		for _, callee := range callees {
+			if _, ok := calls[callee]; ok {
+				called[callee] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(calls))
+	for name := range calls {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	depths := make(map[string]int, len(calls))
+	var queue []string
+	visit := func(name string, depth int) {
+		if _, ok := calls[name]; !ok {
+			return
+		}
+		if _, seen := depths[name]; !seen {
+			depths[name] = depth
+			queue = append(queue, name)
+		}
+	}
+	walk := func() {
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			for _, callee := range calls[name] {
+				visit(callee, depths[name]+1)
+			}
+		}
+	}
+
+	for _, name := range names {
+		if !called[name] {
+			visit(name, 1)
+		}
+	}
+	walk()
+	for _, name := range names {
+		visit(name, 1)
+		walk()
+	}
+	return depths
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+func TestCascadeDepths(t *testing.T) {
+	f := func(name string, calls ...string) unusedfunc.UnusedFunction {
+		return unusedfunc.UnusedFunction{Name: name, Calls: calls}
+	}
+
+	tests := []struct {
+		name     string
+		findings []unusedfunc.UnusedFunction
+		want     map[string]int
+	}{
+		{
+			name:     "empty",
+			findings: nil,
+			want:     map[string]int{},
+		},
+		{
+			name:     "chain",
+			findings: []unusedfunc.UnusedFunction{f("p.c"), f("p.b", "p.c"), f("p.a", "p.b")},
+			want:     map[string]int{"p.a": 1, "p.b": 2, "p.c": 3},
+		},
+		{
+			name:     "shallowest_caller_wins",
+			findings: []unusedfunc.UnusedFunction{f("p.a", "p.b", "p.c"), f("p.b", "p.c"), f("p.c")},
+			want:     map[string]int{"p.a": 1, "p.b": 2, "p.c": 2},
+		},
+		{
+			name:     "calls_to_used_functions_ignored",
+			findings: []unusedfunc.UnusedFunction{f("p.a", "p.used"), f("p.b")},
+			want:     map[string]int{"p.a": 1, "p.b": 1},
+		},
+		{
+			name:     "cycle_starts_at_smallest_name",
+			findings: []unusedfunc.UnusedFunction{f("p.c", "p.b"), f("p.b", "p.c")},
+			want:     map[string]int{"p.b": 1, "p.c": 2},
+		},
+		{
+			name:     "cycle_below_top",
+			findings: []unusedfunc.UnusedFunction{f("p.a", "p.c"), f("p.b", "p.c"), f("p.c", "p.b")},
+			want:     map[string]int{"p.a": 1, "p.c": 2, "p.b": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, cascadeDepths(tt.findings))
+		})
+	}
+}
+
+func TestLimitDepth(t *testing.T) {
+	chain := func() *report.Result {
+		return &report.Result{
+			UnusedFunctions: []unusedfunc.UnusedFunction{
+				{Name: "p.a", Calls: []string{"p.b"}},
+				{Name: "p.b", Calls: []string{"p.c"}},
+				{Name: "p.c"},
+				{Name: "p.lone"},
+			},
+			Stats: report.Stats{UnusedFunctions: 4},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		want     []string
+	}{
+		{name: "top_only", maxDepth: 1, want: []string{"p.a", "p.lone"}},
+		{name: "two_levels", maxDepth: 2, want: []string{"p.a", "p.b", "p.lone"}},
+		{name: "deeper_than_chain", maxDepth: 5, want: []string{"p.a", "p.b", "p.c", "p.lone"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := chain()
+			limitDepth(result, tt.maxDepth)
+
+			var got []string
+			for _, f := range result.UnusedFunctions {
+				got = append(got, f.Name)
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, len(tt.want), result.Stats.UnusedFunctions)
+		})
+	}
+}
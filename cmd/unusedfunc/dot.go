@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// writeDot writes a GraphViz digraph of the unsuppressed findings to the
+// file path, with an edge for each call between two of them. Findings are
+// grouped in one cluster per package, so that the functions to delete
+// together stand out.
+func writeDot(path string, findings []unusedfunc.UnusedFunction) error {
+	byPackage := make(map[string][]unusedfunc.UnusedFunction)
+	reported := make(map[string]bool)
+	for _, f := range findings {
+		if f.Suppressed || reported[f.Name] {
+			continue
+		}
+		reported[f.Name] = true
+		byPackage[f.Package] = append(byPackage[f.Package], f)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	w := bufio.NewWriter(file)
+
+	fmt.Fprintln(w, "digraph unusedfunc {")
+	fmt.Fprintln(w, "\tnode [shape=box];")
+	pkgs := make([]string, 0, len(byPackage))
+	for pkg := range byPackage {
+		pkgs = append(pkgs, pkg)
+	}
+	slices.Sort(pkgs)
+	for i, pkg := range pkgs {
+		fmt.Fprintf(w, "\tsubgraph cluster_%d {\n", i)
+		fmt.Fprintf(w, "\t\tlabel=%s;\n", dotQuote(pkg))
+		for _, f := range byPackage[pkg] {
+			label := strings.TrimPrefix(f.Name, pkg+".")
+			tooltip := fmt.Sprintf("%s:%d (%s)", f.Position.Filename, f.Position.Line, f.Reason)
+			fmt.Fprintf(w, "\t\t%s [label=%s, tooltip=%s];\n", dotQuote(f.Name), dotQuote(label), dotQuote(tooltip))
+		}
+		fmt.Fprintln(w, "\t}")
+	}
+	for _, pkg := range pkgs {
+		for _, f := range byPackage[pkg] {
+			for _, callee := range f.Calls {
+				if reported[callee] {
+					fmt.Fprintf(w, "\t%s -> %s;\n", dotQuote(f.Name), dotQuote(callee))
+				}
+			}
+		}
+	}
+	fmt.Fprintln(w, "}")
+
+	if err := w.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// dotQuote returns s as a double-quoted DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
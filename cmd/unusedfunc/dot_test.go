@@ -0,0 +1,95 @@
+package main
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+func TestWriteDot(t *testing.T) {
+	finding := func(pkg, name string, line int, calls ...string) unusedfunc.UnusedFunction {
+		return unusedfunc.UnusedFunction{
+			Name:     pkg + "." + name,
+			Package:  pkg,
+			Position: token.Position{Filename: "a.go", Line: line},
+			Reason:   unusedfunc.UnexportedReason,
+			Calls:    calls,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		findings []unusedfunc.UnusedFunction
+		want     string
+	}{
+		{
+			name: "empty",
+			want: "digraph unusedfunc {\n\tnode [shape=box];\n}\n",
+		},
+		{
+			name: "clusters_sorted_by_package_with_calls_among_findings",
+			findings: []unusedfunc.UnusedFunction{
+				finding("example.com/b", "g", 7),
+				finding("example.com/a", "f", 3, "example.com/b.g", "example.com/a.used"),
+			},
+			want: `digraph unusedfunc {
+	node [shape=box];
+	subgraph cluster_0 {
+		label="example.com/a";
+		"example.com/a.f" [label="f", tooltip="a.go:3 (unexported and unused)"];
+	}
+	subgraph cluster_1 {
+		label="example.com/b";
+		"example.com/b.g" [label="g", tooltip="a.go:7 (unexported and unused)"];
+	}
+	"example.com/a.f" -> "example.com/b.g";
+}
+`,
+		},
+		{
+			name: "suppressed_and_duplicates_skipped",
+			findings: []unusedfunc.UnusedFunction{
+				finding("example.com/a", "f", 3, "example.com/a.h"),
+				finding("example.com/a", "f", 3),
+				{Name: "example.com/a.h", Package: "example.com/a", Suppressed: true},
+			},
+			want: `digraph unusedfunc {
+	node [shape=box];
+	subgraph cluster_0 {
+		label="example.com/a";
+		"example.com/a.f" [label="f", tooltip="a.go:3 (unexported and unused)"];
+	}
+}
+`,
+		},
+		{
+			name: "quoted",
+			findings: []unusedfunc.UnusedFunction{
+				finding("example.com/a", `*T[\"x\"].m`, 1),
+			},
+			want: `digraph unusedfunc {
+	node [shape=box];
+	subgraph cluster_0 {
+		label="example.com/a";
+		"example.com/a.*T[\\\"x\\\"].m" [label="*T[\\\"x\\\"].m", tooltip="a.go:1 (unexported and unused)"];
+	}
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "dead.dot")
+			require.NoError(t, writeDot(path, tt.findings))
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// runEstimate lists the packages without loading their syntax or types and
+// writes what analyzing them would cost to w.
+func runEstimate(ctx context.Context, cfg *Config, w io.Writer) error {
+	est, err := unusedfunc.EstimateCost(ctx, unusedfunc.LoaderOptions{
+		Packages:  cfg.Packages,
+		BuildTags: cfg.BuildTags,
+		NoTests:   !cfg.Tests,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cfg.Format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(est)
+	}
+
+	_, err = fmt.Fprintf(w, "packages: %d (plus %d dependencies)\nfiles: %d\nfunctions: ~%d\nestimated duration: ~%s\n",
+		est.Packages, est.Dependencies, est.Files, est.Functions, est.Duration.Round(time.Second))
+	return err
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "unused_found",
+			err:  errWithCode(nil, exitUnusedFound),
+			want: exitUnusedFound,
+		},
+		{
+			name: "coded_error",
+			err:  errWithCode(errors.New("analyze: boom"), exitError),
+			want: exitError,
+		},
+		{
+			name: "wrapped_coded_error",
+			err:  fmt.Errorf("run: %w", errWithCode(nil, exitUnusedFound)),
+			want: exitUnusedFound,
+		},
+		{
+			name: "plain_error",
+			err:  errors.New("unknown flag"),
+			want: exitError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, exitCode(tt.err))
+		})
+	}
+}
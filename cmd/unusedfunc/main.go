@@ -8,37 +8,99 @@ import (
 	"fmt"
 	"go/token"
 	"go/types"
+	"io"
 	"log/slog"
 	"maps"
 	"os"
+	"regexp"
 	"runtime"
 	"runtime/pprof"
 	"slices"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/715d/unusedfunc/internal/analysis"
+	"github.com/715d/unusedfunc/pkg/codeowners"
+	"github.com/715d/unusedfunc/pkg/report"
 	"github.com/715d/unusedfunc/pkg/unusedfunc"
 )
 
 // Config holds all command-line configuration options for the unusedfunc analyzer.
 type Config struct {
-	Packages      []string // the Go packages to analyze
-	Verbose       bool     // enables detailed output and statistics
-	JSON          bool     // enables JSON output format
-	BuildTags     []string // build tags to use during package loading
-	Profile       bool     // enables CPU and memory profiling
-	SkipGenerated bool     // skip files with generated code markers
-	Strict        bool     // report ALL unused exported functions (not just /internal)
+	Packages           []string      // the Go packages to analyze
+	Verbose            bool          // enables detailed output and statistics
+	JSON               bool          // enables JSON output format (alias for Format "json")
+	Format             string        // name of the registered output format
+	Oneline            bool          // print a one-line summary (alias for Format "oneline")
+	PackageFormat      string        // how text output shows packages: "full" import paths or "short" names
+	BuildTags          []string      // build tags to use during package loading
+	Profile            bool          // enables CPU and memory profiling
+	ProfileMemInterval time.Duration // writes numbered heap profiles at this interval during the run
+	SkipGenerated      bool          // skip files with generated code markers
+	Strict             bool          // report ALL unused exported functions (not just /internal)
+	CodeOwners         string        // path to a CODEOWNERS file used to group findings by owner
+	MaxRTAVisits       int           // stop reachability analysis after this many function visits
+	RootMarker         string        // report paths relative to the nearest directory containing this file
+	InstantiationCount bool          // add the number of instantiations to the findings for generic functions
+	DumpImplements     string        // write the interface implementation graph as JSON to this file
+	ReportEmptyInit    bool          // also report init functions whose body has no effect
+	CompareWith        string        // only report findings absent from this previous JSON report or baseline
+	ShowRemoved        bool          // with CompareWith, also list findings fixed since the report
+	PackageRegex       string        // only report functions in packages whose import path matches
+	ChangedAfter       string        // only report findings in files last changed after this date
+	ChangedBefore      string        // only report findings in files last changed before this date
+	BenchmarkOnly      bool          // report exported functions reachable only from benchmarks
+	ExampleOnly        bool          // report exported functions reachable only from examples
+	Verify             bool          // cross-check findings against CHA and print disagreements
+	Tests              bool          // load test files so that usage from tests counts
+	TestSupport        string        // regexp matching test-support packages whose exports are reported when unused
+	OnlyReason         string        // only answer this question, e.g. "unexported", trading other results for speed
+	UnexportedRecv     bool          // classify exported methods on unexported types as unexported
+	AssumeImpl         []string      // <interface>=<type> pairs assumed to be the only implementations
+	SuppressionAudit   bool          // tally suppression comment styles instead of analyzing
+	AssumeUsedIfaces   []string      // interfaces called from outside the analyzed code
+	Estimate           bool          // estimate the cost of the analysis instead of running it
+	K8sAware           bool          // keep generated DeepCopy* methods in packages importing k8s.io/apimachinery
+	HelpURLBase        string        // documentation of the reasons, linked from each JSON finding
+	ConfigPrint        bool          // print the effective configuration as JSON instead of analyzing
+	NormalizeGenerics  bool          // accepted for explicitness; generic functions are always reported at their template
+	CascadeTypes       bool          // report unused methods of exported types no analyzed code refers to
+	SummaryStderr      bool          // write a one-line summary to stderr, with or without findings
+	AssumeRemoved      []string      // functions to analyze as if deleted, reporting what only they keep alive
+	Dot                string        // write a GraphViz graph of the findings and the calls among them to this file
+	IgnoreAsserts      bool          // do not let var _ I = (*T)(nil) assertions keep the methods of T
+	MaxDepth           int           // only report findings this many levels down their dead call chains (0 = all)
+	CheckGenerated     bool          // only report unused functions in generated files
+	ListReasons        bool          // print the reasons findings are reported for instead of analyzing
+	NewSince           string        // only report findings absent at this git revision, in files changed since
+	MergeReceivers     bool          // report a type whose methods are all unused as one finding
+	ReflectErrors      bool          // give findings in packages importing reflect full confidence
+	Manifest           string        // write a JSON manifest of the run, for provenance, to this file
+	SuggestDeprecs     bool          // name the used function likely superseding an unused exported one
+	Dual               bool          // also report, marked strict-only, what strict mode would add
+	ReportUnimported   bool          // report unused exports of library packages no analyzed package imports
+	WriteBaseline      string        // write the findings, sorted by canonical name, to this baseline file
+	BaselineFormat     string        // format of WriteBaseline: json or txt
+	PreciseUnnamed     bool          // calls through unnamed interfaces only reach types converted to them
+	OwnersMap          string        // path to a JSON or YAML map of path prefixes to teams used to group findings
+	FormatVersion      int           // shape of JSON reports to write, for consumers of an older one
+	ExcludePackages    []string      // import paths of packages whose functions are not reported
+	ExcludedOnlyUsers  bool          // report functions whose every caller lives in an excluded package
 }
 
 const (
 	exitUnusedFound = 1
 	exitError       = 2
+	exitTruncated   = 3 // analysis stopped at --max-rta-iterations
 )
 
+// onlyUnexported is the --only-reason value restricting the analysis to
+// unexported functions.
+const onlyUnexported = "unexported"
+
 var (
 	// Set via ldflags during build.
 	version   = "dev"
@@ -62,7 +124,8 @@ It reports:
   unusedfunc pkg1 pkg2               # Analyze specific packages
   unusedfunc -v ./internal           # Verbose output
   unusedfunc -json . > report.json   # JSON output to file
-  unusedfunc --strict ./...          # Report ALL unused exports`,
+  unusedfunc --strict ./...          # Report ALL unused exports
+  unusedfunc - < main.go             # Check a single file read from stdin`,
 		Args:               cobra.ArbitraryArgs,
 		RunE:               runCommand,
 		PersistentPreRunE:  setup,
@@ -77,23 +140,82 @@ It reports:
 
 	// Define flags.
 	rootCmd.PersistentFlags().BoolVarP(&cfg.Verbose, "verbose", "v", false, "Enable verbose output")
-	rootCmd.PersistentFlags().BoolVar(&cfg.JSON, "json", false, "Output in JSON format")
+	rootCmd.PersistentFlags().BoolVar(&cfg.JSON, "json", false, "Output in JSON format (same as --format json)")
+	rootCmd.PersistentFlags().StringVar(&cfg.PackageFormat, "package-format", "full", "How text output shows packages: full (import path) or short (package name)")
+	rootCmd.PersistentFlags().BoolVar(&cfg.Oneline, "oneline", false, "Print a one-line summary (same as --format oneline)")
+	rootCmd.PersistentFlags().StringVar(&cfg.Format, "format", "", fmt.Sprintf("Output format, one of %v (default \"text\")", report.Names()))
 	rootCmd.PersistentFlags().StringSliceVar(&cfg.BuildTags, "build-tags", []string{}, "Build tags to use during package loading")
 	rootCmd.PersistentFlags().BoolVar(&cfg.Profile, "profile", false, "Enable CPU and memory profiling (writes cpu.prof and mem.prof to current directory)")
+	rootCmd.PersistentFlags().DurationVar(&cfg.ProfileMemInterval, "profile-mem-interval", 0, "Write a heap profile to mem-001.prof, mem-002.prof, ... in the current directory at this interval (e.g. 500ms)")
 	rootCmd.PersistentFlags().BoolVar(&cfg.SkipGenerated, "skip-generated", true, "Skip files with generated code markers (e.g., '// Code generated')")
 	rootCmd.PersistentFlags().BoolVar(&cfg.Strict, "strict", false, "Report ALL unused exported functions (not just those in /internal)")
+	rootCmd.PersistentFlags().StringVar(&cfg.CodeOwners, "codeowners", "", "Group findings by owner using the given CODEOWNERS file")
+	rootCmd.PersistentFlags().StringVar(&cfg.RootMarker, "root-marker", "", "Report paths relative to the nearest parent directory containing this file (e.g. WORKSPACE)")
+	rootCmd.PersistentFlags().BoolVar(&cfg.InstantiationCount, "instantiation-counts", true, "Add to each finding for a generic function, reported once for its template, the number of its distinct instantiations")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ReportEmptyInit, "report-empty-init", false, "Also report init functions whose body has no effect (no calls, no assignments to package state)")
+	rootCmd.PersistentFlags().StringVar(&cfg.DumpImplements, "dump-implements", "", "Write the interface implementation graph computed during analysis as JSON to this file")
+	rootCmd.PersistentFlags().StringVar(&cfg.CompareWith, "compare-with", "", "Only report findings that are not in the given JSON report from a previous run, or baseline from --write-baseline")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ShowRemoved, "show-removed", false, "With --compare-with, also list findings of the previous report that are fixed")
+	rootCmd.PersistentFlags().StringVar(&cfg.PackageRegex, "package-regex", "", "Only report functions in packages whose import path matches this regular expression; all packages are still analyzed")
+	rootCmd.PersistentFlags().StringVar(&cfg.ChangedAfter, "changed-after", "", "Only report findings in files last changed after this date (YYYY-MM-DD or RFC 3339), per git history or modification time")
+	rootCmd.PersistentFlags().StringVar(&cfg.ChangedBefore, "changed-before", "", "Only report findings in files last changed before this date (YYYY-MM-DD or RFC 3339), per git history or modification time")
+	rootCmd.PersistentFlags().BoolVar(&cfg.BenchmarkOnly, "flag-benchmark-only", false, "Report exported functions that are reachable only from Benchmark functions")
+	rootCmd.PersistentFlags().StringVar(&cfg.TestSupport, "test-support-pattern", "", "Regular expression matching test-support package paths (e.g. '/(testutil|testhelpers)$') whose unused exported functions are reported like internal ones")
+	rootCmd.PersistentFlags().StringVar(&cfg.OnlyReason, "only-reason", "", "Only report findings for this reason, skipping work the others need; the only supported value is 'unexported'")
+	rootCmd.PersistentFlags().BoolVar(&cfg.UnexportedRecv, "unexported-receivers-as-unexported", false, "Report unused exported methods on unexported types with the 'unexported and unused' reason instead of their own")
+	rootCmd.PersistentFlags().StringSliceVar(&cfg.AssumeImpl, "assume-impl", nil, "UNSAFE: assume <interface>=<type> (e.g. example.com/store.Store=example.com/store.memStore) is the only implementation of the interface, to find code another configuration would leave dead")
+	rootCmd.PersistentFlags().BoolVar(&cfg.SuppressionAudit, "suppression-audit", false, "Instead of analyzing, count the nolint:unusedfunc, lint:ignore unusedfunc, unusedfunc:ignore, lint:file-ignore unusedfunc and bare nolint comments and list where they are")
+	rootCmd.PersistentFlags().StringSliceVar(&cfg.AssumeUsedIfaces, "assume-interface-used", nil, "Treat the methods of this interface (e.g. example.com/lib.Visitor) as called by external code, keeping them on every implementation")
+	rootCmd.PersistentFlags().BoolVar(&cfg.Estimate, "estimate", false, "Instead of analyzing, list the packages, files and approximate functions an analysis would cover, with a rough time estimate")
+	rootCmd.PersistentFlags().BoolVar(&cfg.K8sAware, "k8s-aware", false, "Keep the generated DeepCopy* methods of types in packages importing k8s.io/apimachinery, which the API machinery calls")
+	rootCmd.PersistentFlags().StringVar(&cfg.HelpURLBase, "help-url-base", report.DefaultHelpURLBase, "URL of the reason documentation; JSON findings link to <base>#<reason>, e.g. #unexported-and-unused. Empty omits the links")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ConfigPrint, "config-print", false, "Print the effective configuration, defaults included, as JSON and exit")
+	rootCmd.PersistentFlags().BoolVar(&cfg.NormalizeGenerics, "normalize-generics", false, "Report each generic function or method once, by its template name and at its source declaration. This is the default; the flag is an alias kept for configurations that ask for it explicitly")
+	rootCmd.PersistentFlags().BoolVar(&cfg.CascadeTypes, "cascade-types", false, "Report the unused methods of exported types that no analyzed code refers to, instead of keeping them as library API")
+	rootCmd.PersistentFlags().BoolVar(&cfg.SummaryStderr, "summary-stderr", false, "Also write a one-line summary such as 'unusedfunc: OK (0 findings, 1234 functions, 2.3s)' to stderr, whatever the output format")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ExampleOnly, "flag-example-only", false, "Report exported functions that are reachable only from Example functions, documented but otherwise unused")
+	rootCmd.PersistentFlags().StringSliceVar(&cfg.AssumeRemoved, "assume-removed", nil, "Analyze as if this function (e.g. example.com/pkg.Func or example.com/pkg.Type.Method) were deleted, and also report the functions only it keeps alive")
+	rootCmd.PersistentFlags().StringVar(&cfg.Dot, "dot", "", "Write a GraphViz graph of the reported functions and the calls among them to this file, to see which ones to delete together")
+	rootCmd.PersistentFlags().BoolVar(&cfg.IgnoreAsserts, "ignore-compile-asserts", false, "Do not count compile-time assertions such as 'var _ I = (*T)(nil)' as uses, so that they no longer keep the methods of T that I requires")
+	rootCmd.PersistentFlags().IntVar(&cfg.MaxDepth, "max-depth", 0, "Only report the functions at most this many calls down a chain of unused functions, the ones to delete first; later runs reveal the rest (0 = all)")
+	rootCmd.PersistentFlags().BoolVar(&cfg.CheckGenerated, "check-generated", false, "Only report unused functions in generated files, such as stale helpers left in wire_gen.go; findings are reported, never fixed")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ListReasons, "list-reasons", false, "Print the category and exact text of every reason a finding can be reported for, as JSON with --format json, and exit")
+	rootCmd.PersistentFlags().StringVar(&cfg.NewSince, "new-since", "", "Only report functions that were not unused at this git revision and whose file changed since; analyzes a checkout of the revision too")
+	rootCmd.PersistentFlags().BoolVar(&cfg.MergeReceivers, "merge-receivers", false, "Report a type whose methods are all unused as a single finding at its declaration instead of one per method")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ReflectErrors, "reflect-errors", false, "Report findings in packages importing reflect with high confidence, failing the run like other findings, instead of as low-confidence notes")
+	rootCmd.PersistentFlags().StringVar(&cfg.Manifest, "manifest", "", "Write a JSON manifest of the run to this file: tool and Go versions, arguments, effective configuration, analyzed packages and stats")
+	rootCmd.PersistentFlags().BoolVar(&cfg.SuggestDeprecs, "suggest-deprecations", false, "Point unused exported functions to a used one named like them plus V2, New or a version number, which likely supersedes them")
+	rootCmd.PersistentFlags().BoolVar(&cfg.Dual, "dual", false, "Also report the functions only the public API of library packages keeps alive, as strict mode would, marked strict-only; they do not fail the run")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ReportUnimported, "report-unimported-packages", false, "Report the unused exported functions of library packages that no analyzed package imports, like internal ones; they can only be API for code outside the analyzed packages")
+	rootCmd.PersistentFlags().StringVar(&cfg.WriteBaseline, "write-baseline", "", "Write the unsuppressed findings, sorted by package, receiver and name, to this baseline file, which --compare-with reads")
+	rootCmd.PersistentFlags().StringVar(&cfg.BaselineFormat, "baseline-format", baselineJSON, "Format of --write-baseline: json, or txt for one function name per line")
+	rootCmd.PersistentFlags().BoolVar(&cfg.PreciseUnnamed, "precise-unnamed-interfaces", false, "Only keep the methods called through an unnamed interface, e.g. a parameter of type interface{ Close() error }, on the types converted to it, rather than on every type implementing it; unsafe if values reach it from code outside the analysis")
+	rootCmd.PersistentFlags().StringVar(&cfg.OwnersMap, "owners-map", "", "Group findings by team using the given JSON or YAML map of path prefixes, relative to its directory, to teams; the longest matching prefix wins")
+	rootCmd.PersistentFlags().IntVar(&cfg.FormatVersion, "format-version", report.JSONFormatVersion, "Version of the shape of JSON reports to write; see docs/reference/json-format.md")
+	rootCmd.PersistentFlags().StringSliceVar(&cfg.ExcludePackages, "exclude-package", nil, "Do not report the functions of the package with this import path; its calls still keep the functions of other packages alive")
+	rootCmd.PersistentFlags().BoolVar(&cfg.ExcludedOnlyUsers, "report-excluded-only-users", false, "Also report the functions whose every caller lives in a package of --exclude-package, with the reason 'used only by excluded packages'")
+	rootCmd.PersistentFlags().BoolVar(&cfg.Tests, "tests", true, "Load test files; with --tests=false, functions used only by tests are reported")
+	rootCmd.PersistentFlags().BoolVar(&cfg.Verify, "verify", false, "Cross-check findings against Class Hierarchy Analysis and print potential false positives to stderr")
+	_ = rootCmd.PersistentFlags().MarkHidden("verify")
+	rootCmd.PersistentFlags().IntVar(&cfg.MaxRTAVisits, "max-rta-iterations", 10_000_000, "Stop reachability analysis after this many function visits and report partial results, with a warning on stderr and exit code 3 (0 = unlimited)")
 
 	if err := rootCmd.Execute(); err != nil {
 		_ = teardown(nil, nil)
 		if err.Error() != "" {
 			fmt.Fprintln(os.Stderr, err.Error())
 		}
-		var cErr codedError
-		if errors.As(err, &cErr) {
-			os.Exit(cErr.code)
-		}
-		os.Exit(exitError)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode returns the process exit code for an error returned by the root
+// command: the code attached with errWithCode, or exitError otherwise.
+func exitCode(err error) int {
+	var cErr *codedError
+	if errors.As(err, &cErr) {
+		return cErr.code
 	}
+	return exitError
 }
 
 func runCommand(cmd *cobra.Command, args []string) error {
@@ -103,36 +225,180 @@ func runCommand(cmd *cobra.Command, args []string) error {
 		cfg.Packages = []string{"./..."}
 	}
 
+	if cfg.ConfigPrint {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(effectiveConfig(cmd.Flags(), cfg.Packages)); err != nil {
+			return errWithCode(fmt.Errorf("print config: %w", err), exitError)
+		}
+		return nil
+	}
+
+	if cfg.ListReasons {
+		if err := listReasons(os.Stdout, cfg.Format == "json"); err != nil {
+			return errWithCode(fmt.Errorf("list reasons: %w", err), exitError)
+		}
+		return nil
+	}
+
+	if cfg.SuppressionAudit {
+		if err := runSuppressionAudit(cmd.Context(), &cfg, os.Stdout); err != nil {
+			return errWithCode(fmt.Errorf("suppression audit: %w", err), exitError)
+		}
+		return nil
+	}
+
+	if cfg.Estimate {
+		if err := runEstimate(cmd.Context(), &cfg, os.Stdout); err != nil {
+			return errWithCode(fmt.Errorf("estimate: %w", err), exitError)
+		}
+		return nil
+	}
+
 	slog.Info("starting unused function analysis", "packages", cfg.Packages)
 
-	result, err := runAnalysis(cmd.Context(), &cfg)
+	var changedAfter, changedBefore time.Time
+	if cfg.ChangedAfter != "" {
+		var err error
+		if changedAfter, err = parseDate(cfg.ChangedAfter); err != nil {
+			return errWithCode(fmt.Errorf("--changed-after: %w", err), exitError)
+		}
+	}
+	if cfg.ChangedBefore != "" {
+		var err error
+		if changedBefore, err = parseDate(cfg.ChangedBefore); err != nil {
+			return errWithCode(fmt.Errorf("--changed-before: %w", err), exitError)
+		}
+	}
+
+	if cfg.MaxDepth < 0 {
+		return errWithCode(fmt.Errorf("--max-depth must not be negative"), exitError)
+	}
+
+	result, err := runAnalysis(cmd.Context(), &cfg, "")
 	if err != nil {
 		return errWithCode(fmt.Errorf("analyze: %w", err), exitError)
 	}
 
+	// The baseline records every finding, before the options below narrow
+	// the report to some of them.
+	if cfg.WriteBaseline != "" {
+		if err := writeBaseline(cfg.WriteBaseline, cfg.BaselineFormat, result.UnusedFunctions); err != nil {
+			return errWithCode(fmt.Errorf("baseline: %w", err), exitError)
+		}
+	}
+
+	if cfg.MaxDepth > 0 {
+		limitDepth(result, cfg.MaxDepth)
+	}
+
+	if cfg.CompareWith != "" {
+		if err := compareWithReport(result, cfg.CompareWith); err != nil {
+			return errWithCode(fmt.Errorf("compare: %w", err), exitError)
+		}
+		if !cfg.ShowRemoved {
+			result.Removed = nil
+		}
+	}
+
+	if cfg.NewSince != "" {
+		if err := filterNewSince(cmd.Context(), result, &cfg); err != nil {
+			return errWithCode(fmt.Errorf("--new-since: %w", err), exitError)
+		}
+	}
+
+	if !changedAfter.IsZero() || !changedBefore.IsZero() {
+		filterByChangeDate(cmd.Context(), result, changedAfter, changedBefore)
+	}
+
+	if cfg.CodeOwners != "" {
+		rs, err := codeowners.Load(cfg.CodeOwners)
+		if err != nil {
+			return errWithCode(fmt.Errorf("codeowners: %w", err), exitError)
+		}
+		assignOwners(result, rs)
+	}
+	if cfg.OwnersMap != "" {
+		m, err := codeowners.LoadPrefixMap(cfg.OwnersMap)
+		if err != nil {
+			return errWithCode(fmt.Errorf("owners map: %w", err), exitError)
+		}
+		assignOwners(result, m)
+	}
+
+	// Ownership is resolved from absolute paths, so relativize afterwards.
+	if cfg.RootMarker != "" {
+		relativizeToMarker(result, cfg.RootMarker)
+	}
+
+	result.Stats.EstimatedRemovableLines = estimateRemovableLines(result.UnusedFunctions)
+
+	if cfg.Dot != "" {
+		if err := writeDot(cfg.Dot, result.UnusedFunctions); err != nil {
+			return errWithCode(fmt.Errorf("dot: %w", err), exitError)
+		}
+	}
+
+	if cfg.Manifest != "" {
+		if err := writeManifest(cfg.Manifest, &cfg, result); err != nil {
+			return errWithCode(fmt.Errorf("manifest: %w", err), exitError)
+		}
+	}
+
+	// Merging only changes how findings are presented, so it comes after
+	// everything computed from individual methods.
+	if cfg.MergeReceivers {
+		mergeReceivers(result)
+	}
+
 	if err := writeResults(result, &cfg); err != nil {
 		return errWithCode(fmt.Errorf("format results: %w", err), exitError)
 	}
+	if cfg.SummaryStderr {
+		writeSummary(os.Stderr, result)
+	}
 
-	if len(result.UnusedFunctions) > 0 {
+	if result.Stats.Truncated {
+		return errWithCode(nil, exitTruncated)
+	}
+	if failingFindings(result) > 0 {
 		return errWithCode(nil, exitUnusedFound)
 	}
 	return nil
 }
 
-// Result represents the analysis output for a single package including
-// all unused functions and execution statistics.
-type Result struct {
-	UnusedFunctions []unusedfunc.UnusedFunction `json:"unused_functions"`
-	Stats           struct {
-		TotalFunctions      int           `json:"total_functions"`
-		UnusedFunctions     int           `json:"unused_functions"`
-		SuppressedFunctions int           `json:"suppressed_functions"`
-		AnalysisDuration    time.Duration `json:"analysis_duration"`
-	} `json:"stats"`
+// failingFindings counts the findings that fail the run: all but the
+// low-confidence and strict-only ones.
+func failingFindings(result *report.Result) int {
+	n := 0
+	for _, f := range result.UnusedFunctions {
+		if f.Confidence != unusedfunc.LowConfidence && !f.StrictOnly {
+			n++
+		}
+	}
+	return n
+}
+
+// writeSummary writes a line such as
+// "unusedfunc: OK (0 findings, 1234 functions, 2.3s)" to w, saying that the
+// analysis ran whatever the output format.
+func writeSummary(w io.Writer, result *report.Result) {
+	status := "OK"
+	if failingFindings(result) > 0 {
+		status = "FAIL"
+	}
+	findings := "findings"
+	if len(result.UnusedFunctions) == 1 {
+		findings = "finding"
+	}
+	fmt.Fprintf(w, "unusedfunc: %s (%d %s, %d functions, %s)\n", status,
+		len(result.UnusedFunctions), findings, result.Stats.TotalFunctions,
+		result.Stats.AnalysisDuration.Round(100*time.Millisecond))
 }
 
-func runAnalysis(ctx context.Context, cfg *Config) (*Result, error) {
+// runAnalysis loads cfg.Packages from dir, or from the current directory if
+// dir is empty, and analyzes them.
+func runAnalysis(ctx context.Context, cfg *Config, dir string) (*report.Result, error) {
 	start := time.Now()
 
 	slog.Info("loading packages", "packages", cfg.Packages)
@@ -140,10 +406,58 @@ func runAnalysis(ctx context.Context, cfg *Config) (*Result, error) {
 		slog.Info("using build tags", "tags", cfg.BuildTags)
 	}
 
-	pkgs, err := unusedfunc.LoadPackages(ctx, unusedfunc.LoaderOptions{
+	loaderOpts := unusedfunc.LoaderOptions{
 		Packages:  cfg.Packages,
 		BuildTags: cfg.BuildTags,
-	})
+		NoTests:   !cfg.Tests,
+		Dir:       dir,
+	}
+
+	var stdin *stdinSource
+	if slices.Contains(cfg.Packages, stdinArg) {
+		if len(cfg.Packages) > 1 {
+			return nil, fmt.Errorf("%q cannot be combined with other packages", stdinArg)
+		}
+		var err error
+		if stdin, err = readStdinSource(os.Stdin); err != nil {
+			return nil, err
+		}
+		defer stdin.Close()
+		stdin.apply(&loaderOpts)
+	}
+
+	var packageRegex *regexp.Regexp
+	if cfg.PackageRegex != "" {
+		var err error
+		if packageRegex, err = regexp.Compile(cfg.PackageRegex); err != nil {
+			return nil, fmt.Errorf("invalid --package-regex: %w", err)
+		}
+	}
+
+	var testSupport *regexp.Regexp
+	if cfg.TestSupport != "" {
+		var err error
+		if testSupport, err = regexp.Compile(cfg.TestSupport); err != nil {
+			return nil, fmt.Errorf("invalid --test-support-pattern: %w", err)
+		}
+	}
+
+	var assumeImpl map[string]string
+	for _, pair := range cfg.AssumeImpl {
+		iface, impl, ok := strings.Cut(pair, "=")
+		if !ok || iface == "" || impl == "" {
+			return nil, fmt.Errorf("invalid --assume-impl %q: want <interface>=<type>", pair)
+		}
+		if assumeImpl == nil {
+			assumeImpl = make(map[string]string)
+		}
+		assumeImpl[iface] = impl
+	}
+	if len(assumeImpl) > 0 {
+		slog.Warn("assuming single implementations; findings may be wrong for other configurations", "assume_impl", assumeImpl)
+	}
+
+	pkgs, err := unusedfunc.LoadPackages(ctx, loaderOpts)
 	if err != nil {
 		return nil, fmt.Errorf("loading packages: %w", err)
 	}
@@ -151,22 +465,86 @@ func runAnalysis(ctx context.Context, cfg *Config) (*Result, error) {
 
 	slog.Info("running analysis")
 	analyzer := unusedfunc.NewAnalyzer(unusedfunc.AnalyzerOptions{
-		SkipGenerated: cfg.SkipGenerated,
-		Strict:        cfg.Strict,
+		SkipGenerated:            cfg.SkipGenerated,
+		CheckGenerated:           cfg.CheckGenerated,
+		Strict:                   cfg.Strict,
+		MaxRTAVisits:             cfg.MaxRTAVisits,
+		ReportEmptyInit:          cfg.ReportEmptyInit,
+		PackageRegex:             packageRegex,
+		BenchmarkOnly:            cfg.BenchmarkOnly,
+		ExampleOnly:              cfg.ExampleOnly,
+		Verify:                   cfg.Verify,
+		TestSupportPattern:       testSupport,
+		ReportUnimported:         cfg.ReportUnimported,
+		UnexportedOnly:           cfg.OnlyReason == onlyUnexported,
+		AssumeImpl:               assumeImpl,
+		AssumeInterfaceUsed:      cfg.AssumeUsedIfaces,
+		K8sAware:                 cfg.K8sAware,
+		CascadeTypes:             cfg.CascadeTypes,
+		AssumeRemoved:            cfg.AssumeRemoved,
+		ExcludePackages:          cfg.ExcludePackages,
+		ReportExcludedOnlyUsers:  cfg.ExcludedOnlyUsers,
+		DeadCalls:                cfg.Dot != "" || cfg.MaxDepth > 0,
+		IgnoreCompileAsserts:     cfg.IgnoreAsserts,
+		Dual:                     cfg.Dual,
+		PreciseUnnamedInterfaces: cfg.PreciseUnnamed,
 	})
 	result, err := analyzer.Analyze(pkgs)
 	if err != nil {
 		return nil, fmt.Errorf("analyze packages: %w", err)
 	}
+	if cfg.DumpImplements != "" {
+		if err := writeImplements(cfg.DumpImplements, analyzer.Implements()); err != nil {
+			return nil, fmt.Errorf("dump implements: %w", err)
+		}
+	}
 	duration := time.Since(start)
 	slog.Info("analysis completed", "dur", duration)
 
-	return convertToResult(result, duration), nil
+	// Disagreements are diagnostics for tuning the analysis, not findings.
+	for _, f := range analyzer.Disagreements() {
+		fmt.Fprintf(os.Stderr, "verify: %s:%d:%d %s (%s)\n",
+			f.Position.Filename, f.Position.Line, f.Position.Column, f.Name, f.Reason)
+	}
+
+	r := convertToResult(result, duration, cfg)
+	if analyzer.Truncated() {
+		// Partial results look like a clean run; say so whatever the
+		// verbosity.
+		fmt.Fprintf(os.Stderr, "unusedfunc: reachability analysis stopped after %d function visits (--max-rta-iterations); findings may include used functions\n", cfg.MaxRTAVisits)
+		r.Stats.Truncated = true
+	}
+	for _, pkg := range pkgs {
+		r.Packages = append(r.Packages, pkg.PkgPath)
+	}
+	slices.Sort(r.Packages)
+	r.Packages = slices.Compact(r.Packages)
+	if calls := analyzer.DeadCalls(); calls != nil {
+		for i := range r.UnusedFunctions {
+			r.UnusedFunctions[i].Calls = calls[r.UnusedFunctions[i].Name]
+		}
+	}
+	for _, f := range analyzer.EmptyInits() {
+		if f.Suppressed {
+			r.Stats.SuppressedFunctions++
+			continue
+		}
+		r.UnusedFunctions = append(r.UnusedFunctions, f)
+		r.Stats.UnusedFunctions++
+	}
+	if stdin != nil {
+		stdin.relabel(r)
+	}
+	return r, nil
 }
 
-func convertToResult(funcs map[types.Object]*analysis.FuncInfo, dur time.Duration) *Result {
-	var r Result
+func convertToResult(funcs map[types.Object]*analysis.FuncInfo, dur time.Duration, cfg *Config) *report.Result {
+	var r report.Result
 	r.Stats.AnalysisDuration = dur
+	var superseded map[types.Object]string
+	if cfg.SuggestDeprecs {
+		superseded = successors(funcs)
+	}
 
 	sortedFuncs := slices.SortedFunc(maps.Values(funcs), func(a, b *analysis.FuncInfo) int {
 		// Sort by package path, then by name.
@@ -184,7 +562,10 @@ func convertToResult(funcs map[types.Object]*analysis.FuncInfo, dur time.Duratio
 			r.Stats.SuppressedFunctions++
 		}
 
-		if f.ShouldReport() {
+		// With --only-reason unexported, exported functions were not
+		// analyzed accurately and must not be reported.
+		strictOnly := cfg.Dual && !f.ShouldReport() && f.ShouldReportStrict()
+		if (f.ShouldReport() || strictOnly) && (cfg.OnlyReason != onlyUnexported || !f.IsExported) {
 			pos := token.NoPos
 			if f.DeclarationPos.IsValid() {
 				pos = f.DeclarationPos
@@ -203,28 +584,68 @@ func convertToResult(funcs map[types.Object]*analysis.FuncInfo, dur time.Duratio
 
 			var reason string
 			switch {
+			case f.UnusedAfterRemoval:
+				reason = unusedfunc.AssumedRemovedReason
+			case f.UsedOnlyByExcluded:
+				reason = unusedfunc.ExcludedOnlyReason
+			case f.UsedOnlyByBenchmarks:
+				reason = unusedfunc.BenchmarksReason
+			case f.UsedOnlyByExamples:
+				reason = unusedfunc.ExamplesReason
 			case !f.IsExported:
-				reason = "unexported and unused"
+				reason = unusedfunc.UnexportedReason
+			case f.HasUnexportedReceiver() && cfg.UnexportedRecv:
+				reason = unusedfunc.UnexportedReason
+			case f.HasUnexportedReceiver():
+				reason = unusedfunc.UnexportedReceiverReason
 			case f.IsInInternalPackage():
-				reason = "exported in internal and unused"
+				reason = unusedfunc.InternalReason
+			case f.IsInTestSupport:
+				reason = unusedfunc.TestSupportReason
+			case f.IsInUnimported:
+				reason = unusedfunc.UnimportedReason
 			case f.Package != nil && f.Package.Name == "main":
-				reason = "exported in main and unused"
-			case f.Strict:
-				reason = "exported and unused (strict mode)"
+				reason = unusedfunc.MainReason
+			case f.OnUnreferencedType:
+				reason = unusedfunc.UnreferencedTypeReason
+			case f.Strict || strictOnly:
+				reason = unusedfunc.StrictReason
 			}
 
-			packagePath := ""
+			packagePath, packageName := "", ""
 			if f.Package != nil {
-				packagePath = f.Package.PkgPath
+				packagePath, packageName = f.Package.PkgPath, f.Package.Name
 			}
 
-			r.UnusedFunctions = append(r.UnusedFunctions, unusedfunc.UnusedFunction{
-				Name:       f.Name,
-				Position:   position,
-				Reason:     reason,
-				Suppressed: f.IsSuppressed,
-				Package:    packagePath,
-			})
+			finding := unusedfunc.UnusedFunction{
+				Name:           f.Name,
+				Position:       position,
+				Reason:         reason,
+				Suppressed:     f.IsSuppressed,
+				Package:        packagePath,
+				PackageName:    packageName,
+				Instantiations: len(f.Instantiations),
+				Lines:          unusedfunc.DeclLines(f),
+				Confidence:     unusedfunc.HighConfidence,
+				SupersededBy:   superseded[f.Object],
+				StrictOnly:     strictOnly,
+			}
+			// Functions of packages using reflection may be called
+			// through it.
+			if f.Package != nil && f.Package.Imports["reflect"] != nil && !cfg.ReflectErrors {
+				finding.Confidence = unusedfunc.LowConfidence
+			}
+			if named := f.ReceiverType(); named != nil && named.Obj().Pkg() != nil {
+				finding.Receiver = named.Obj().Pkg().Path() + "." + named.Obj().Name()
+				finding.ReceiverMethods = named.NumMethods()
+				if f.Package != nil && f.Package.Fset != nil {
+					finding.ReceiverPos = f.Package.Fset.Position(named.Obj().Pos())
+				}
+			}
+			if !cfg.InstantiationCount {
+				finding.Instantiations = 0
+			}
+			r.UnusedFunctions = append(r.UnusedFunctions, finding)
 			r.Stats.UnusedFunctions++
 		}
 	}
@@ -232,125 +653,141 @@ func convertToResult(funcs map[types.Object]*analysis.FuncInfo, dur time.Duratio
 	return &r
 }
 
-func writeResults(result *Result, cfg *Config) error {
-	var output string
-	var err error
-
-	if cfg.JSON {
-		output, err = formatJSONOutput(result)
-	} else {
-		output = formatTextOutput(result, cfg)
+// estimateRemovableLines sums the declaration lines of the unsuppressed
+// findings for unexported functions. Findings sharing a declaration are
+// counted once.
+func estimateRemovableLines(functions []unusedfunc.UnusedFunction) int {
+	seen := make(map[token.Position]bool)
+	total := 0
+	for _, f := range functions {
+		if f.Suppressed || f.Reason != unusedfunc.UnexportedReason || seen[f.Position] {
+			continue
+		}
+		seen[f.Position] = true
+		total += f.Lines
 	}
+	return total
+}
 
+// writeImplements writes the interface implementation graph to path as JSON,
+// mapping each interface to the types implementing it.
+func writeImplements(path string, graph map[string][]string) error {
+	if graph == nil {
+		graph = map[string][]string{}
+	}
+	data, err := json.MarshalIndent(graph, "", "  ")
 	if err != nil {
-		return err
+		return fmt.Errorf("marshal graph: %w", err)
 	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
 
-	fmt.Print(output)
-	return nil
+// assignOwners resolves the owners of every finding, from a CODEOWNERS file
+// or an owners map.
+func assignOwners(result *report.Result, owners interface{ Owners(string) []string }) {
+	for i := range result.UnusedFunctions {
+		f := &result.UnusedFunctions[i]
+		f.Owners = owners.Owners(f.Position.Filename)
+	}
 }
 
-func formatJSONOutput(result *Result) (string, error) {
-	functions := make([]jFunction, 0, len(result.UnusedFunctions))
-	for _, function := range result.UnusedFunctions {
-		functions = append(functions, jFunction{
-			Name:       function.Name,
-			File:       function.Position.Filename,
-			Line:       function.Position.Line,
-			Column:     function.Position.Column,
-			Reason:     function.Reason,
-			Suppressed: function.Suppressed,
-			Package:    function.Package,
-		})
-	}
-
-	data, err := json.MarshalIndent(jOutput{
-		UnusedFunctions: functions,
-		Stats:           result.Stats,
-		Version:         version,
-		Timestamp:       time.Now().UTC().Format(time.RFC3339),
-	}, "", "  ")
+func writeResults(result *report.Result, cfg *Config) error {
+	formatter, err := report.New(cfg.Format, report.Options{
+		Verbose:       cfg.Verbose,
+		GroupByOwner:  cfg.CodeOwners != "" || cfg.OwnersMap != "",
+		ShortPackage:  cfg.PackageFormat == "short",
+		Version:       version,
+		HelpURLBase:   cfg.HelpURLBase,
+		FormatVersion: cfg.FormatVersion,
+	})
 	if err != nil {
-		return "", fmt.Errorf("marshaling json output: %w", err)
+		return err
 	}
-	return string(data), nil
+	return formatter.Format(result, os.Stdout)
 }
 
-func formatTextOutput(result *Result, cfg *Config) string {
-	var output strings.Builder
-
-	if cfg.Verbose {
-		slog.Info("",
-			"total_functions", result.Stats.TotalFunctions,
-			"unused_functions", result.Stats.UnusedFunctions,
-			"suppressed_functions", result.Stats.SuppressedFunctions,
-			"analysis_duration", result.Stats.AnalysisDuration.String())
+// listReasons writes the reasons findings are reported for, one per line
+// after its category, or as a JSON array.
+func listReasons(w io.Writer, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(unusedfunc.Reasons())
 	}
-
-	if len(result.UnusedFunctions) == 0 {
-		slog.Info("no unused functions found")
-		return output.String()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, r := range unusedfunc.Reasons() {
+		fmt.Fprintf(tw, "%s\t%s\n", r.Category, r.Reason)
 	}
+	return tw.Flush()
+}
 
-	// Group functions by package for better organization.
-	packageFunctions := make(map[string][]unusedfunc.UnusedFunction)
-	for _, f := range result.UnusedFunctions {
-		packageFunctions[f.Package] = append(packageFunctions[f.Package], f)
-	}
+var (
+	cpuProfile       *os.File
+	stopHeapSampling func()
+)
 
-	for pkg, functions := range packageFunctions {
-		if len(packageFunctions) > 1 && cfg.Verbose {
-			output.WriteString(fmt.Sprintf("\n%s:\n", pkg))
+func setup(_ *cobra.Command, _ []string) error {
+	// --json predates --format and is kept as an alias, like --oneline.
+	for _, alias := range []struct {
+		name string
+		set  bool
+	}{{"json", cfg.JSON}, {"oneline", cfg.Oneline}} {
+		if !alias.set {
+			continue
 		}
-
-		for _, f := range functions {
-			// Format: filename:line:column functionName (reason)
-			if !cfg.Verbose {
-				// Compact format for non-verbose mode.
-				output.WriteString(fmt.Sprintf("%s:%d:%d %s\n",
-					f.Position.Filename, f.Position.Line, f.Position.Column, f.Name))
-			} else {
-				output.WriteString(fmt.Sprintf("  %s:%d:%d %s (%s)\n",
-					f.Position.Filename, f.Position.Line, f.Position.Column, f.Name, f.Reason))
-			}
+		if cfg.Format != "" && cfg.Format != alias.name {
+			return errWithCode(fmt.Errorf("--%s conflicts with --format %s", alias.name, cfg.Format), exitError)
 		}
+		cfg.Format = alias.name
+	}
+	if cfg.Format == "" {
+		cfg.Format = "text"
+	}
+	if _, err := report.New(cfg.Format, report.Options{}); err != nil {
+		return errWithCode(err, exitError)
+	}
+	if cfg.PackageFormat != "full" && cfg.PackageFormat != "short" {
+		return errWithCode(fmt.Errorf("invalid --package-format %q: want full or short", cfg.PackageFormat), exitError)
+	}
+	if err := report.CheckJSONFormatVersion(cfg.FormatVersion); err != nil {
+		return errWithCode(fmt.Errorf("invalid --format-version: %w", err), exitError)
+	}
+	if cfg.CodeOwners != "" && cfg.OwnersMap != "" {
+		return errWithCode(errors.New("--codeowners conflicts with --owners-map"), exitError)
+	}
+	if cfg.BaselineFormat != baselineJSON && cfg.BaselineFormat != baselineText {
+		return errWithCode(fmt.Errorf("invalid --baseline-format %q: want %s or %s", cfg.BaselineFormat, baselineJSON, baselineText), exitError)
+	}
+	if cfg.ExcludedOnlyUsers && len(cfg.ExcludePackages) == 0 {
+		return errWithCode(errors.New("--report-excluded-only-users requires --exclude-package"), exitError)
+	}
+	if cfg.OnlyReason != "" && cfg.OnlyReason != onlyUnexported {
+		return errWithCode(fmt.Errorf("invalid --only-reason %q: want %s", cfg.OnlyReason, onlyUnexported), exitError)
+	}
+	if cfg.OnlyReason != "" && cfg.ReportEmptyInit {
+		return errWithCode(errors.New("--only-reason conflicts with --report-empty-init"), exitError)
+	}
+	if cfg.ProfileMemInterval < 0 {
+		return errWithCode(fmt.Errorf("invalid --profile-mem-interval %s: must be positive", cfg.ProfileMemInterval), exitError)
 	}
 
-	return output.String()
-}
-
-type jOutput struct {
-	UnusedFunctions []jFunction `json:"unused_functions"`
-	Stats           any         `json:"stats"`
-	Version         string      `json:"version"`
-	Timestamp       string      `json:"timestamp"`
-}
-
-type jFunction struct {
-	Name       string `json:"name"`
-	File       string `json:"file"`
-	Line       int    `json:"line"`
-	Column     int    `json:"column"`
-	Reason     string `json:"reason"`
-	Suppressed bool   `json:"suppressed"`
-	Package    string `json:"package"`
-}
-
-var cpuProfile *os.File
-
-func setup(_ *cobra.Command, _ []string) error {
 	// Disable logger unless verbose flag is set.
 	slog.SetDefault(slog.New(slog.DiscardHandler))
 	if cfg.Verbose {
 		opts := &slog.HandlerOptions{Level: slog.LevelDebug}
 		var handler slog.Handler = slog.NewTextHandler(os.Stderr, opts)
-		if cfg.JSON {
+		if cfg.Format == "json" {
 			handler = slog.NewJSONHandler(os.Stderr, opts)
 		}
 		logger := slog.New(handler)
 		slog.SetDefault(logger)
 	}
 
+	if cfg.ProfileMemInterval > 0 {
+		stopHeapSampling = startHeapSampling(cfg.ProfileMemInterval)
+		slog.Info("heap sampling started", "interval", cfg.ProfileMemInterval)
+	}
+
 	if !cfg.Profile {
 		return nil
 	}
@@ -370,6 +807,10 @@ func setup(_ *cobra.Command, _ []string) error {
 }
 
 func teardown(_ *cobra.Command, _ []string) error {
+	if stopHeapSampling != nil {
+		stopHeapSampling()
+	}
+
 	if !cfg.Profile || cpuProfile == nil {
 		return nil
 	}
@@ -380,14 +821,9 @@ func teardown(_ *cobra.Command, _ []string) error {
 	slog.Info("cpu profiling stopped", "file", "cpu.prof")
 
 	// Write memory profile.
-	memFile, err := os.Create("mem.prof")
-	if err != nil {
-		return fmt.Errorf("creating mem.prof: %w", err)
-	}
-	defer memFile.Close()
 	runtime.GC() // Get up-to-date statistics
-	if err := pprof.WriteHeapProfile(memFile); err != nil {
-		return fmt.Errorf("writing memory profile: %w", err)
+	if err := writeHeapProfile("mem.prof"); err != nil {
+		return err
 	}
 	slog.Info("memory profiling completed", "file", "mem.prof")
 	return nil
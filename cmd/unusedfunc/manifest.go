@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/715d/unusedfunc/pkg/report"
+)
+
+// manifest records how a run was made, so that its report can be traced
+// back to, and reproduced from, the tool, arguments and packages it came
+// from.
+type manifest struct {
+	Version   string       `json:"version"`
+	Commit    string       `json:"commit"`
+	BuildTime string       `json:"build_time"`
+	GoVersion string       `json:"go_version"`
+	Timestamp string       `json:"timestamp"`
+	Args      []string     `json:"args"`
+	Config    *Config      `json:"config"`
+	BuildTags []string     `json:"build_tags"`
+	Packages  []string     `json:"packages"`
+	Stats     report.Stats `json:"stats"`
+}
+
+// writeManifest writes the manifest of the run configured by cfg, which
+// produced result, to path.
+func writeManifest(path string, cfg *Config, result *report.Result) error {
+	data, err := json.MarshalIndent(manifest{
+		Version:   version,
+		Commit:    gitCommit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Args:      os.Args[1:],
+		Config:    cfg,
+		BuildTags: cfg.BuildTags,
+		Packages:  result.Packages,
+		Stats:     result.Stats,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"go/token"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// mergeReceivers replaces the findings for the methods of a type whose
+// methods are all unused with one finding at the declaration of the type,
+// where the first of them was listed. Types with used methods keep a
+// finding per unused method.
+func mergeReceivers(result *report.Result) {
+	whole := make(map[string]bool)
+	for _, t := range report.TypeRollups(result.UnusedFunctions) {
+		if t.AllUnused() {
+			whole[t.Type] = true
+		}
+	}
+	if len(whole) == 0 {
+		return
+	}
+
+	merged := make(map[string]int) // type -> index in kept
+	counted := make(map[token.Position]bool)
+	var kept []unusedfunc.UnusedFunction
+	for _, f := range result.UnusedFunctions {
+		if f.Suppressed || !whole[f.Receiver] {
+			kept = append(kept, f)
+			continue
+		}
+		// Findings sharing a declaration share its lines.
+		lines := f.Lines
+		if counted[f.Position] {
+			lines = 0
+		}
+		counted[f.Position] = true
+		if i, ok := merged[f.Receiver]; ok {
+			kept[i].Lines += lines
+			continue
+		}
+		merged[f.Receiver] = len(kept)
+		kept = append(kept, unusedfunc.UnusedFunction{
+			Name:            f.Receiver,
+			Position:        f.ReceiverPos,
+			Reason:          unusedfunc.UnusedTypeReason,
+			Package:         f.Package,
+			PackageName:     f.PackageName,
+			Owners:          f.Owners,
+			Lines:           lines,
+			ReceiverMethods: f.ReceiverMethods,
+			Confidence:      f.Confidence,
+		})
+	}
+	result.UnusedFunctions = kept
+}
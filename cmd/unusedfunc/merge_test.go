@@ -0,0 +1,115 @@
+package main
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+func TestMergeReceivers(t *testing.T) {
+	typePos := token.Position{Filename: "a.go", Line: 1}
+	method := func(name string, line int, receiver string, methods int) unusedfunc.UnusedFunction {
+		return unusedfunc.UnusedFunction{
+			Name:            receiver + "." + name,
+			Position:        token.Position{Filename: "a.go", Line: line},
+			Reason:          unusedfunc.UnexportedReason,
+			Package:         "example.com/a",
+			PackageName:     "a",
+			Lines:           3,
+			Receiver:        receiver,
+			ReceiverMethods: methods,
+			ReceiverPos:     typePos,
+			Confidence:      unusedfunc.HighConfidence,
+		}
+	}
+	merged := func(receiver string, lines, methods int) unusedfunc.UnusedFunction {
+		return unusedfunc.UnusedFunction{
+			Name:            receiver,
+			Position:        typePos,
+			Reason:          unusedfunc.UnusedTypeReason,
+			Package:         "example.com/a",
+			PackageName:     "a",
+			Lines:           lines,
+			ReceiverMethods: methods,
+			Confidence:      unusedfunc.HighConfidence,
+		}
+	}
+	fn := unusedfunc.UnusedFunction{Name: "example.com/a.f", Position: token.Position{Filename: "a.go", Line: 40}}
+
+	tests := []struct {
+		name     string
+		findings []unusedfunc.UnusedFunction
+		want     []unusedfunc.UnusedFunction
+	}{
+		{
+			name:     "no_methods",
+			findings: []unusedfunc.UnusedFunction{fn},
+			want:     []unusedfunc.UnusedFunction{fn},
+		},
+		{
+			name: "all_methods_unused",
+			findings: []unusedfunc.UnusedFunction{
+				method("a", 5, "example.com/a.T", 2),
+				fn,
+				method("b", 9, "example.com/a.T", 2),
+			},
+			want: []unusedfunc.UnusedFunction{
+				merged("example.com/a.T", 6, 2),
+				fn,
+			},
+		},
+		{
+			name: "some_methods_used",
+			findings: []unusedfunc.UnusedFunction{
+				method("a", 5, "example.com/a.S", 3),
+				method("b", 9, "example.com/a.S", 3),
+			},
+			want: []unusedfunc.UnusedFunction{
+				method("a", 5, "example.com/a.S", 3),
+				method("b", 9, "example.com/a.S", 3),
+			},
+		},
+		{
+			name: "suppressed_method_counts_as_used",
+			findings: []unusedfunc.UnusedFunction{
+				method("a", 5, "example.com/a.T", 2),
+				func() unusedfunc.UnusedFunction {
+					m := method("b", 9, "example.com/a.T", 2)
+					m.Suppressed = true
+					return m
+				}(),
+			},
+			want: []unusedfunc.UnusedFunction{
+				method("a", 5, "example.com/a.T", 2),
+				func() unusedfunc.UnusedFunction {
+					m := method("b", 9, "example.com/a.T", 2)
+					m.Suppressed = true
+					return m
+				}(),
+			},
+		},
+		{
+			name: "shared_declaration_counted_once",
+			findings: []unusedfunc.UnusedFunction{
+				method("a", 5, "example.com/a.T", 2),
+				method("a", 5, "example.com/a.T", 2),
+				method("b", 9, "example.com/a.T", 2),
+			},
+			want: []unusedfunc.UnusedFunction{
+				merged("example.com/a.T", 6, 2),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &report.Result{UnusedFunctions: tt.findings}
+			mergeReceivers(result)
+			require.Equal(t, tt.want, result.UnusedFunctions)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/715d/unusedfunc/pkg/report"
+)
+
+// filterNewSince keeps only the findings that are new since the git revision
+// cfg.NewSince: functions that were not reported when analyzing a checkout
+// of the revision, and whose file was added or changed since. Findings are
+// matched by name, as with --compare-with.
+func filterNewSince(ctx context.Context, result *report.Result, cfg *Config) error {
+	if slices.Contains(cfg.Packages, stdinArg) {
+		return fmt.Errorf("cannot be combined with %q", stdinArg)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	top, err := git(ctx, cwd, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return err
+	}
+	// git reports the top level with symbolic links resolved, so the working
+	// directory must be too for it to be inside.
+	cwd, err = filepath.EvalSymlinks(cwd)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(top, cwd)
+	if err != nil {
+		return err
+	}
+
+	changed, err := changedFiles(ctx, top, cfg.NewSince)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp("", "unusedfunc-new-since-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	worktree := filepath.Join(tmp, "base")
+	if _, err := git(ctx, top, "worktree", "add", "--detach", worktree, cfg.NewSince); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := git(context.WithoutCancel(ctx), top, "worktree", "remove", "--force", worktree); err != nil {
+			slog.Warn("removing worktree", "dir", worktree, "error", err)
+		}
+	}()
+
+	slog.Info("analyzing base revision", "rev", cfg.NewSince)
+	baseCfg := *cfg
+	baseCfg.DumpImplements, baseCfg.Verify = "", false
+	base, err := runAnalysis(ctx, &baseCfg, filepath.Join(worktree, rel))
+	if err != nil {
+		return fmt.Errorf("analyze %s: %w", cfg.NewSince, err)
+	}
+	wasUnused := make(map[string]bool, len(base.UnusedFunctions))
+	for _, f := range base.UnusedFunctions {
+		wasUnused[f.Name] = true
+	}
+
+	kept := result.UnusedFunctions[:0]
+	for _, f := range result.UnusedFunctions {
+		// git reports paths with symbolic links resolved.
+		filename, err := filepath.EvalSymlinks(f.Position.Filename)
+		if err != nil {
+			filename = f.Position.Filename
+		}
+		if !wasUnused[f.Name] && changed[filename] {
+			kept = append(kept, f)
+		}
+	}
+	slog.Info("kept findings new since base revision", "rev", cfg.NewSince,
+		"kept", len(kept), "hidden", len(result.UnusedFunctions)-len(kept))
+	result.UnusedFunctions = kept
+	result.Stats.UnusedFunctions = len(kept)
+	return nil
+}
+
+// changedFiles returns the absolute paths of the files of the work tree at
+// top that differ from rev, untracked files included.
+func changedFiles(ctx context.Context, top, rev string) (map[string]bool, error) {
+	diff, err := git(ctx, top, "diff", "--name-only", "--no-renames", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git(ctx, top, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	changed := make(map[string]bool)
+	for _, name := range strings.Split(diff+"\n"+untracked, "\n") {
+		if name != "" {
+			changed[filepath.Join(top, name)] = true
+		}
+	}
+	return changed, nil
+}
+
+// git runs git in dir and returns its trimmed standard output.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
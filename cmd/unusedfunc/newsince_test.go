@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterNewSince(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping package loading in short mode")
+	}
+
+	dir := initGitRepo(t)
+	files := map[string]string{
+		"go.mod":  "module example.com/ns\n\ngo 1.24\n",
+		"main.go": "package main\n\nfunc main() { helper() }\n\nfunc helper() { libHelper() }\n\nfunc old() {}\n",
+		"lib.go":  "package main\n\nfunc libHelper() {}\n\nfunc libUnused() {}\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+		commitFile(t, dir, name, "2024-01-01T00:00:00Z")
+	}
+
+	// main no longer calls helper, which leaves helper and, in the unchanged
+	// lib.go, libHelper unused; fresh and brandNew are new.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"),
+		[]byte("package main\n\nfunc main() {}\n\nfunc helper() { libHelper() }\n\nfunc old() {}\n\nfunc fresh() {}\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.go"),
+		[]byte("package main\n\nfunc brandNew() {}\n"), 0o600))
+
+	tests := []struct {
+		name string
+		// link runs the analysis from a symbolic link to the repository.
+		link bool
+	}{
+		{name: "repository"},
+		{name: "symbolic_link", link: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wd := dir
+			if tt.link {
+				wd = filepath.Join(t.TempDir(), "link")
+				require.NoError(t, os.Symlink(dir, wd))
+			}
+			t.Chdir(wd)
+
+			config := &Config{Packages: []string{"./..."}, Tests: true, SkipGenerated: true, NewSince: "HEAD"}
+			result, err := runAnalysis(context.Background(), config, "")
+			require.NoError(t, err)
+			require.NoError(t, filterNewSince(context.Background(), result, config))
+
+			var got []string
+			for _, f := range result.UnusedFunctions {
+				got = append(got, f.Name)
+			}
+			slices.Sort(got)
+			require.Equal(t, []string{"example.com/ns.brandNew", "example.com/ns.fresh", "example.com/ns.helper"}, got)
+			require.Equal(t, len(got), result.Stats.UnusedFunctions)
+		})
+	}
+}
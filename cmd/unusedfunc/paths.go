@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/715d/unusedfunc/pkg/report"
+)
+
+// markerRoots resolves, for each file, the nearest enclosing directory that
+// contains a marker file such as WORKSPACE or .monorepo-root.
+type markerRoots struct {
+	marker string
+	roots  map[string]string // directory -> marker root ("" if none)
+}
+
+func newMarkerRoots(marker string) *markerRoots {
+	return &markerRoots{
+		marker: marker,
+		roots:  make(map[string]string),
+	}
+}
+
+// root returns the marker root for dir, walking up until the marker is found.
+// Every directory visited is cached, so files in sibling directories share
+// the walk.
+func (m *markerRoots) root(dir string) string {
+	var visited []string
+	root := ""
+	for {
+		if r, ok := m.roots[dir]; ok {
+			root = r
+			break
+		}
+		visited = append(visited, dir)
+		if _, err := os.Stat(filepath.Join(dir, m.marker)); err == nil {
+			root = dir
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	for _, d := range visited {
+		m.roots[d] = root
+	}
+	return root
+}
+
+// rel returns filename relative to its marker root. Files without a marker
+// root, and filenames that are not absolute, are returned unchanged.
+func (m *markerRoots) rel(filename string) string {
+	if !filepath.IsAbs(filename) {
+		return filename
+	}
+	root := m.root(filepath.Dir(filename))
+	if root == "" {
+		return filename
+	}
+	rel, err := filepath.Rel(root, filename)
+	if err != nil {
+		return filename
+	}
+	return rel
+}
+
+// relativizeToMarker rewrites finding positions relative to the nearest
+// directory containing marker, so paths are the same regardless of where
+// the tool is invoked from.
+func relativizeToMarker(result *report.Result, marker string) {
+	roots := newMarkerRoots(marker)
+	for i := range result.UnusedFunctions {
+		f := &result.UnusedFunctions[i]
+		f.Position.Filename = roots.rel(f.Position.Filename)
+		f.ReceiverPos.Filename = roots.rel(f.ReceiverPos.Filename)
+	}
+}
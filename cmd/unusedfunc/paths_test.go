@@ -0,0 +1,79 @@
+package main
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+func TestRelativizeToMarker(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"WORKSPACE",
+		"svc/api/main.go",
+		"svc/nested/WORKSPACE",
+		"svc/nested/lib/lib.go",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+	}
+
+	tests := []struct {
+		name     string
+		marker   string
+		filename string
+		want     string
+	}{
+		{
+			name:     "nearest_root",
+			marker:   "WORKSPACE",
+			filename: filepath.Join(dir, "svc/api/main.go"),
+			want:     filepath.Join("svc", "api", "main.go"),
+		},
+		{
+			name:     "nested_root_wins",
+			marker:   "WORKSPACE",
+			filename: filepath.Join(dir, "svc/nested/lib/lib.go"),
+			want:     filepath.Join("lib", "lib.go"),
+		},
+		{
+			name:     "no_marker",
+			marker:   ".monorepo-root",
+			filename: filepath.Join(dir, "svc/api/main.go"),
+			want:     filepath.Join(dir, "svc/api/main.go"),
+		},
+		{
+			name:     "relative_unchanged",
+			marker:   "WORKSPACE",
+			filename: filepath.Join("svc", "api", "main.go"),
+			want:     filepath.Join("svc", "api", "main.go"),
+		},
+		{
+			name:     "empty_unchanged",
+			marker:   "WORKSPACE",
+			filename: "",
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &report.Result{UnusedFunctions: []unusedfunc.UnusedFunction{{
+				Name:        "example.com/p.f",
+				Position:    token.Position{Filename: tt.filename, Line: 3},
+				ReceiverPos: token.Position{Filename: tt.filename, Line: 1},
+			}}}
+			relativizeToMarker(result, tt.marker)
+			require.Equal(t, tt.want, result.UnusedFunctions[0].Position.Filename)
+			require.Equal(t, tt.want, result.UnusedFunctions[0].ReceiverPos.Filename)
+			require.Equal(t, 3, result.UnusedFunctions[0].Position.Line)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// startHeapSampling writes a heap profile to mem-001.prof, mem-002.prof, ...
+// every interval until the returned function is called, so that memory
+// peaks can be attributed to a phase of the analysis. The numbered files do
+// not collide with the mem.prof written by --profile at exit.
+//
+// Unlike the final profile, samples do not force a garbage collection: they
+// show the heap as of the last collection, without disturbing the run.
+func startHeapSampling(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for n := 1; ; n++ {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				name := fmt.Sprintf("mem-%03d.prof", n)
+				if err := writeHeapProfile(name); err != nil {
+					slog.Error("heap sampling failed", "error", err)
+					continue
+				}
+				slog.Debug("heap profile written", "file", name)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
+
+// writeHeapProfile writes the current heap profile to the file name.
+func writeHeapProfile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", name, err)
+	}
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing memory profile: %w", err)
+	}
+	return f.Close()
+}
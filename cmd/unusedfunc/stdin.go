@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/715d/unusedfunc/pkg/report"
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+const (
+	// stdinArg is the package argument that reads a Go file from stdin.
+	stdinArg = "-"
+
+	// stdinDisplayName replaces the synthetic filename in reported positions.
+	stdinDisplayName = "<stdin>"
+
+	// stdinGoVersion is the language version of the synthetic module, matching
+	// the minimum Go version unusedfunc itself supports.
+	stdinGoVersion = "1.24"
+)
+
+// stdinSource is a single Go file read from stdin, analyzed as the only
+// package of a throwaway module. The file itself never touches the disk:
+// it is supplied to the loader as an overlay under a synthetic filename.
+type stdinSource struct {
+	dir      string // temporary module root
+	filename string // synthetic absolute path of the file
+	content  []byte
+}
+
+// readStdinSource reads a complete Go source file from r and prepares a
+// temporary module to load it from.
+func readStdinSource(r io.Reader) (*stdinSource, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read stdin: %w", err)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("read stdin: no Go source provided")
+	}
+
+	dir, err := os.MkdirTemp("", "unusedfunc-stdin-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp module: %w", err)
+	}
+	gomod := fmt.Appendf(nil, "module stdin\n\ngo %s\n", stdinGoVersion)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), gomod, 0o600); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("write temp go.mod: %w", err)
+	}
+
+	return &stdinSource{
+		dir:      dir,
+		filename: filepath.Join(dir, "stdin.go"),
+		content:  content,
+	}, nil
+}
+
+// apply configures opts to load the stdin file as the only package.
+func (s *stdinSource) apply(opts *unusedfunc.LoaderOptions) {
+	opts.Packages = []string{"."}
+	opts.Dir = s.dir
+	opts.Overlay = map[string][]byte{s.filename: s.content}
+}
+
+// relabel replaces the synthetic filename in result positions.
+func (s *stdinSource) relabel(result *report.Result) {
+	for i := range result.UnusedFunctions {
+		if result.UnusedFunctions[i].Position.Filename == s.filename {
+			result.UnusedFunctions[i].Position.Filename = stdinDisplayName
+		}
+	}
+}
+
+// Close removes the temporary module.
+func (s *stdinSource) Close() error {
+	return os.RemoveAll(s.dir)
+}
@@ -4,6 +4,7 @@ package analysis
 import (
 	"go/token"
 	"go/types"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -28,6 +29,14 @@ type FuncInfo struct {
 	// IsInInternal indicates whether this function is defined in an internal package.
 	IsInInternal bool
 
+	// IsInTestSupport indicates whether this function is defined in a
+	// test-support package, whose exports are only meant for tests.
+	IsInTestSupport bool
+
+	// IsInUnimported indicates whether this function is defined in a library
+	// package that no analyzed package imports.
+	IsInUnimported bool
+
 	// IsSuppressed indicates whether this function has suppression comments.
 	IsSuppressed bool
 
@@ -46,6 +55,39 @@ type FuncInfo struct {
 	// HasCGoExport indicates whether this function has a //export directive for CGo.
 	HasCGoExport bool
 
+	// HasWasmExport indicates whether this function has a //go:wasmexport
+	// directive, which exports it to the host of a WebAssembly module.
+	HasWasmExport bool
+
+	// UsedOnlyByBenchmarks indicates that this exported function is reachable
+	// only from Benchmark functions. It is then not considered used.
+	UsedOnlyByBenchmarks bool
+
+	// UsedOnlyByExamples indicates that this exported function is reachable
+	// only from Example functions. It is then not considered used.
+	UsedOnlyByExamples bool
+
+	// UnusedAfterRemoval indicates that this function is reachable only
+	// through functions assumed removed (see ssa.Options.AssumeRemoved). It
+	// is then not considered used.
+	UnusedAfterRemoval bool
+
+	// UsedOnlyByExcluded indicates that this function is reachable only
+	// through excluded packages (see ssa.Options.ExcludedPackages). It is
+	// then not considered used.
+	UsedOnlyByExcluded bool
+
+	// OnUnreferencedType indicates that this is a method of an exported type
+	// that no analyzed code refers to (see UnreferencedTypes). Such methods
+	// are not assumed to be public API, so they are reported when unused.
+	OnUnreferencedType bool
+
+	// StrictOnly indicates that this function is used, but only through
+	// the exported functions and methods of library packages, which strict
+	// mode does not take as entry points. It is then reported in strict
+	// mode (see ShouldReportStrict).
+	StrictOnly bool
+
 	// DeclarationPos is the position where this function is declared.
 	DeclarationPos token.Pos
 
@@ -54,6 +96,11 @@ type FuncInfo struct {
 
 	// Strict indicates strict mode where ALL exported functions are checked for usage.
 	Strict bool
+
+	// Instantiations lists, sorted and without duplicates, the type argument
+	// lists this generic function or its generic receiver type is instantiated
+	// with (e.g. "[int]", "[string, *models.User]"). Empty for non-generic functions.
+	Instantiations []string
 }
 
 // NewFuncInfo creates a new FuncInfo for the given function object and package.
@@ -81,6 +128,21 @@ func NewFuncInfo(obj types.Object, pkg *packages.Package, nameCache *NameCache,
 	return fi
 }
 
+// AddInstantiation records an instantiation with the given type arguments.
+func (fi *FuncInfo) AddInstantiation(typeArgs *types.TypeList) {
+	if typeArgs == nil || typeArgs.Len() == 0 {
+		return
+	}
+	args := make([]string, typeArgs.Len())
+	for i := range typeArgs.Len() {
+		args[i] = types.TypeString(typeArgs.At(i), (*types.Package).Name)
+	}
+	inst := "[" + strings.Join(args, ", ") + "]"
+	if i, found := slices.BinarySearch(fi.Instantiations, inst); !found {
+		fi.Instantiations = slices.Insert(fi.Instantiations, i, inst)
+	}
+}
+
 // IsInInternalPackage checks if this function is defined in an internal package.
 func (fi *FuncInfo) IsInInternalPackage() bool {
 	if fi.Package == nil {
@@ -96,6 +158,48 @@ func (fi *FuncInfo) IsInInternalPackage() bool {
 		pkgPath == "internal"
 }
 
+// HasUnexportedReceiver reports whether this function is a method whose
+// receiver type is unexported. Such methods cannot be called from outside
+// the package unless the type is exposed through an exported type or an
+// interface, so even exported ones are rarely part of the API.
+func (fi *FuncInfo) HasUnexportedReceiver() bool {
+	named := fi.ReceiverType()
+	return named != nil && !named.Obj().Exported()
+}
+
+// ReceiverType returns the generic or non-generic named type a method is
+// declared on, or nil for a function.
+func (fi *FuncInfo) ReceiverType() *types.Named {
+	fn, ok := fi.Object.(*types.Func)
+	if !ok {
+		return nil
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return nil
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return nil
+	}
+	return named.Origin()
+}
+
+// ShouldReportStrict determines if this function would be reported as
+// unused in strict mode, when StrictOnly has been computed.
+func (fi *FuncInfo) ShouldReportStrict() bool {
+	strict := *fi
+	strict.Strict = true
+	if fi.StrictOnly {
+		strict.IsUsed = false
+	}
+	return strict.ShouldReport()
+}
+
 // ShouldReport determines if this function should be reported as unused.
 // Returns true if:
 // - Method is unexported and unused, OR
@@ -135,6 +239,11 @@ func (fi *FuncInfo) ShouldReport() bool {
 		return false
 	}
 
+	// Don't report functions exported to a WebAssembly host.
+	if fi.HasWasmExport {
+		return false
+	}
+
 	// Report unexported unused functions.
 	if !fi.IsExported {
 		return true
@@ -142,14 +251,14 @@ func (fi *FuncInfo) ShouldReport() bool {
 
 	// In strict mode, report ALL unused exported functions. This is intended for applications
 	// where no functions are considered part of a public API.
-	if fi.Strict {
+	if fi.Strict || fi.OnUnreferencedType {
 		return true
 	}
 
 	// Normal mode: Report exported unused functions if:
-	// 1. They're in internal packages, OR
+	// 1. They're in internal, test-support or unimported packages, OR
 	// 2. They're in a main package (not externally accessible)
-	if fi.IsInInternal {
+	if fi.IsInInternal || fi.IsInTestSupport || fi.IsInUnimported {
 		return true
 	}
 
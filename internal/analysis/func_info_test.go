@@ -233,3 +233,61 @@ func TestFuncInfo_ShouldReport(t *testing.T) {
 		})
 	}
 }
+
+func TestFuncInfo_AddInstantiation(t *testing.T) {
+	models := types.NewPackage("example.com/app/models", "models")
+	user := types.NewNamed(types.NewTypeName(token.NoPos, models, "User", nil), types.NewStruct(nil, nil), nil)
+
+	// type Pair[K, V any] struct{}
+	tparams := []*types.TypeParam{
+		types.NewTypeParam(types.NewTypeName(token.NoPos, models, "K", nil), types.Universe.Lookup("any").Type()),
+		types.NewTypeParam(types.NewTypeName(token.NoPos, models, "V", nil), types.Universe.Lookup("any").Type()),
+	}
+	pair := types.NewNamed(types.NewTypeName(token.NoPos, models, "Pair", nil), types.NewStruct(nil, nil), nil)
+	pair.SetTypeParams(tparams)
+
+	typeArgs := func(args ...types.Type) *types.TypeList {
+		inst, err := types.Instantiate(nil, pair, args, true)
+		require.NoError(t, err)
+		return inst.(*types.Named).TypeArgs()
+	}
+
+	fi := &FuncInfo{}
+	fi.AddInstantiation(typeArgs(types.Typ[types.String], types.Typ[types.Int]))
+	fi.AddInstantiation(typeArgs(types.Typ[types.Int], types.NewPointer(user)))
+	fi.AddInstantiation(typeArgs(types.Typ[types.String], types.Typ[types.Int]))
+	fi.AddInstantiation(nil)
+
+	require.Equal(t, []string{"[int, *models.User]", "[string, int]"}, fi.Instantiations)
+}
+
+// TestFuncInfo_HasUnexportedReceiver tests receiver visibility detection.
+func TestFuncInfo_HasUnexportedReceiver(t *testing.T) {
+	pkg := types.NewPackage("example.com/test", "test")
+	newNamed := func(name string) *types.Named {
+		return types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), types.NewStruct(nil, nil), nil)
+	}
+	method := func(recv types.Type) types.Object {
+		sig := types.NewSignatureType(types.NewVar(token.NoPos, pkg, "r", recv), nil, nil, nil, nil, false)
+		return types.NewFunc(token.NoPos, pkg, "Method", sig)
+	}
+
+	tests := []struct {
+		name     string
+		obj      types.Object
+		expected bool
+	}{
+		{"function", types.NewFunc(token.NoPos, pkg, "Func", types.NewSignatureType(nil, nil, nil, nil, nil, false)), false},
+		{"exported value receiver", method(newNamed("Exported")), false},
+		{"exported pointer receiver", method(types.NewPointer(newNamed("Exported"))), false},
+		{"unexported value receiver", method(newNamed("impl")), true},
+		{"unexported pointer receiver", method(types.NewPointer(newNamed("impl"))), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFuncInfo(tt.obj, &packages.Package{PkgPath: pkg.Path()}, NewNameCache(), false)
+			require.Equal(t, tt.expected, f.HasUnexportedReceiver())
+		})
+	}
+}
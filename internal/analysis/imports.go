@@ -0,0 +1,29 @@
+package analysis
+
+import (
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// UnimportedPackages returns the import paths of the library packages in
+// pkgs that no other package in pkgs imports. Their own tests do not count
+// as importers. When pkgs is a whole module, nothing can import such a
+// package but code outside it, so its exported API is unlikely to be used.
+func UnimportedPackages(pkgs []*packages.Package) map[string]bool {
+	unimported := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if pkg.Name != "main" && !strings.HasSuffix(pkg.PkgPath, "_test") {
+			unimported[pkg.PkgPath] = true
+		}
+	}
+	for _, pkg := range pkgs {
+		importer := strings.TrimSuffix(strings.TrimSuffix(pkg.PkgPath, ".test"), "_test")
+		for path := range pkg.Imports {
+			if path != importer {
+				delete(unimported, path)
+			}
+		}
+	}
+	return unimported
+}
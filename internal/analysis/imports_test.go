@@ -0,0 +1,30 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestUnimportedPackages(t *testing.T) {
+	pkg := func(name, path string, imports ...string) *packages.Package {
+		p := &packages.Package{Name: name, PkgPath: path, Imports: make(map[string]*packages.Package)}
+		for _, imp := range imports {
+			p.Imports[imp] = &packages.Package{PkgPath: imp}
+		}
+		return p
+	}
+
+	unimported := UnimportedPackages([]*packages.Package{
+		pkg("main", "example.com/cmd/app", "example.com/used", "fmt"),
+		pkg("used", "example.com/used", "example.com/leaf"),
+		pkg("leaf", "example.com/leaf"),
+		pkg("orphan", "example.com/orphan"),
+		// Tests of orphan, in package and external, do not count.
+		pkg("orphan", "example.com/orphan", "example.com/orphan"),
+		pkg("orphan_test", "example.com/orphan_test", "example.com/orphan"),
+		pkg("main", "example.com/orphan.test", "example.com/orphan", "example.com/orphan_test"),
+	})
+	require.Equal(t, map[string]bool{"example.com/orphan": true}, unimported)
+}
@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// UnreferencedTypes returns the exported named types declared in pkgs that
+// no code in pkgs refers to. References from the declaration of a type
+// itself, and from the declarations of its methods, do not count: a type
+// used only by itself is still dead. Only the given packages are searched,
+// so users of a library may still refer to the types returned.
+func UnreferencedTypes(pkgs []*packages.Package) map[*types.TypeName]bool {
+	unreferenced := make(map[*types.TypeName]bool)
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			if tn, ok := scope.Lookup(name).(*types.TypeName); ok && tn.Exported() && !tn.IsAlias() {
+				unreferenced[tn] = true
+			}
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				switch decl := decl.(type) {
+				case *ast.FuncDecl:
+					markReferencedTypes(pkg.TypesInfo, decl, receiverTypeName(pkg.TypesInfo, decl), unreferenced)
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						var self *types.TypeName
+						if ts, ok := spec.(*ast.TypeSpec); ok {
+							self, _ = pkg.TypesInfo.Defs[ts.Name].(*types.TypeName)
+						}
+						markReferencedTypes(pkg.TypesInfo, spec, self, unreferenced)
+					}
+				}
+			}
+		}
+	}
+	return unreferenced
+}
+
+// markReferencedTypes removes the types that node refers to, other than
+// self, from unreferenced.
+func markReferencedTypes(info *types.Info, node ast.Node, self *types.TypeName, unreferenced map[*types.TypeName]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if tn, ok := info.Uses[id].(*types.TypeName); ok && tn != self {
+				delete(unreferenced, tn)
+			}
+		}
+		return true
+	})
+}
+
+// receiverTypeName returns the type whose method decl declares, or nil if
+// decl is a function.
+func receiverTypeName(info *types.Info, decl *ast.FuncDecl) *types.TypeName {
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return nil
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		return named.Origin().Obj()
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestUnreferencedTypes(t *testing.T) {
+	const src = `package p
+
+// Dead refers to itself only, in its fields and methods.
+type Dead struct{ next *Dead }
+
+func (d *Dead) Clone() *Dead { return &Dead{next: d} }
+
+// Returned is mentioned by the signature of NewReturned.
+type Returned struct{}
+
+func NewReturned() *Returned { return nil }
+
+// Embedded is only embedded in Dead2, which is itself unreferenced.
+type Embedded struct{}
+
+type Dead2 struct{ Embedded }
+
+// Literal is used in a function body.
+type Literal struct{}
+
+func use() { _ = Literal{} }
+
+type unexported struct{}`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	info := &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	pkg, err := new(types.Config).Check("example.com/p", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	unreferenced := UnreferencedTypes([]*packages.Package{{
+		PkgPath:   "example.com/p",
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     pkg,
+		TypesInfo: info,
+	}})
+
+	var names []string
+	for tn := range unreferenced {
+		names = append(names, tn.Name())
+	}
+	slices.Sort(names)
+	require.Equal(t, []string{"Dead", "Dead2"}, names)
+}
@@ -35,6 +35,20 @@ type BuildConfiguration struct {
 	// GOARCH sets the target architecture.
 	GOARCH string `yaml:"goarch,omitempty"`
 
+	// K8sAware runs the analysis with AnalyzerOptions.K8sAware.
+	K8sAware bool `yaml:"k8s_aware,omitempty"`
+
+	// CascadeTypes runs the analysis with AnalyzerOptions.CascadeTypes.
+	CascadeTypes bool `yaml:"cascade_types,omitempty"`
+
+	// IgnoreCompileAsserts runs the analysis with
+	// AnalyzerOptions.IgnoreCompileAsserts.
+	IgnoreCompileAsserts bool `yaml:"ignore_compile_asserts,omitempty"`
+
+	// PreciseUnnamedInterfaces runs the analysis with
+	// AnalyzerOptions.PreciseUnnamedInterfaces.
+	PreciseUnnamedInterfaces bool `yaml:"precise_unnamed_interfaces,omitempty"`
+
 	// ExpectedUnused lists the functions expected to be reported as unused for this configuration.
 	ExpectedUnused []ExpectedFunc `yaml:"expected_unused"`
 
@@ -159,7 +173,12 @@ func (h *TestHarness) runConfiguration(t *testing.T, tc *TestCase, cfg BuildConf
 	}
 
 	// Run analysis.
-	result, err := unusedfunc.NewAnalyzer(unusedfunc.AnalyzerOptions{}).Analyze(pkgs)
+	result, err := unusedfunc.NewAnalyzer(unusedfunc.AnalyzerOptions{
+		K8sAware:                 cfg.K8sAware,
+		CascadeTypes:             cfg.CascadeTypes,
+		IgnoreCompileAsserts:     cfg.IgnoreCompileAsserts,
+		PreciseUnnamedInterfaces: cfg.PreciseUnnamedInterfaces,
+	}).Analyze(pkgs)
 	if err != nil {
 		// Check if this error was expected.
 		for _, expectedErr := range cfg.ExpectedErrors {
@@ -268,14 +287,20 @@ func validateResults(cfgResult *ConfigurationResult, expected []ExpectedFunc, ac
 		expectedMap[e.FuncName] = e
 	}
 
+	var details []string
+	success := true
+
+	// A function reported twice means a phantom declaration, e.g. one from a
+	// file excluded by build constraints.
 	actualMap := make(map[string]UnusedFunc)
 	for _, a := range actual {
+		if prev, dup := actualMap[a.Name]; dup {
+			details = append(details, fmt.Sprintf("Reported more than once: %s (%s and %s)", a.Name, prev.File, a.File))
+			success = false
+		}
 		actualMap[a.Name] = a
 	}
 
-	var details []string
-	success := true
-
 	// Check for missing expected functions.
 	var missing []string
 	for key, exp := range expectedMap {
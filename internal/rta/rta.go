@@ -45,6 +45,11 @@
 //    - Automatically marks the generic template (Container[T].Add) as reachable too
 //    - Ensures templates are properly tracked without needing post-processing
 //
+// 9. Order-independent "implements" relation:
+//    - Both sides of the relation are updated whenever a type or interface is first seen
+//    - Type assertions are remembered and applied to implementations discovered later
+//    - Results do not depend on map iteration or worklist order
+//
 // These modifications dramatically reduce false positives in unused function.
 // detection while maintaining correctness and safety. All interface compliance
 // patterns, special runtime calls, and generic templates are now handled in a
@@ -93,7 +98,9 @@ import (
 	"go/types"
 	"hash/crc32"
 	"log/slog"
+	"maps"
 	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/types/typeutil"
@@ -177,11 +184,80 @@ type Result struct {
 	// Types *A, A and B are accessible to reflection, but the unnamed.
 	// type struct{B} is not.
 	RuntimeTypes typeutil.Map
+
+	// Truncated reports whether the analysis stopped at Options.MaxVisits
+	// before reaching a fixed point. Reachable is then incomplete.
+	Truncated bool
+
+	// Implementations maps each interface that was the target of a type
+	// assertion or interface conversion to the program types implementing
+	// it (by value or pointer receiver). Nil if there were none.
+	Implementations map[*types.Interface][]types.Type
+}
+
+// Options configures an RTA run.
+type Options struct {
+	// MaxVisits bounds the number of functions visited. The worklist always
+	// converges in theory, but a marking bug could make it grow without bound;
+	// the limit turns such a hang into a warning and partial results.
+	// Zero means no limit.
+	MaxVisits int
+
+	// AssumeImpl maps interfaces to the only concrete type assumed to be
+	// stored in them. Other implementations are not called through invoke
+	// sites of the interface, and converting them to it does not make the
+	// interface methods reachable. This is unsound unless the assumption
+	// holds at run time, e.g. because a configuration always selects the
+	// same implementation.
+	AssumeImpl map[*types.Interface]types.Type
+
+	// AssumeInvoked lists interfaces whose methods are assumed to be called
+	// from outside the analyzed code, such as by the tests of a consumer of
+	// a library. They are treated as if user code asserted values to them,
+	// so the interface methods of all their implementations are reachable.
+	AssumeInvoked []*types.Interface
+
+	// Removed lists functions and methods to analyze as if they were
+	// deleted from the program: they are never reachable, and neither is
+	// anything only they call. Wrappers and instantiations of a removed
+	// function are removed with it.
+	Removed map[types.Object]bool
+
+	// RemovedPkgs lists packages to analyze as if they were deleted, with
+	// all their functions, methods and initializers.
+	RemovedPkgs map[*types.Package]bool
+
+	// Conversions lists interface conversions that have no instruction in
+	// any function, such as compile-time assertions: the SSA builder drops
+	// "var _ I = (*T)(nil)" because it has no effect. They are analyzed as
+	// if a reachable function converted a T to I.
+	Conversions []Conversion
+
+	// PreciseUnnamedInterfaces restricts the concrete types an invoke site
+	// of an unnamed interface, such as the parameter of
+	// "func f(c interface{ Close() })", may call to those converted to an
+	// interface with the same methods. By default it calls every runtime
+	// type implementing it, wherever that type was converted. This is
+	// unsound when a value reaches the site only through conversions to
+	// other interfaces that are not visible as such, e.g. from code outside
+	// the analyzed packages.
+	PreciseUnnamedInterfaces bool
 }
 
+// Conversion is a conversion of a value of concrete type T to Interface.
+type Conversion struct {
+	T         types.Type
+	Interface *types.Interface
+}
+
+// recentVisits is the number of last visited functions reported when the
+// analysis stops at Options.MaxVisits.
+const recentVisits = 5
+
 // Working state of the RTA algorithm.
 type rta struct {
 	result *Result
+	opts   Options
 
 	prog *ssa.Program
 
@@ -200,32 +276,56 @@ type rta struct {
 	// Keys are *types.Signature, values are unordered []ssa.CallInstruction.
 	dynCallSites typeutil.Map
 
+	// readGlobals contains the package-level variables read by reachable code.
+	readGlobals map[*ssa.Global]bool
+
+	// pendingGlobalFuncs holds, for each unexported package-level variable
+	// not read yet, the functions stored into it, directly or through the
+	// map it is set to. They are only address-taken once the variable is
+	// read: a dispatch table nobody reads does not keep its entries alive.
+	pendingGlobalFuncs map[*ssa.Global][]*ssa.Function
+
 	// invokeSites contains all "invoke"-mode call sites, grouped by interface.
 	// Keys are *types.Interface (never *types.Named),
 	// Values are unordered []ssa.CallInstruction sets.
 	invokeSites typeutil.Map
 
 	// The following two maps together define the subset of the.
-	// m:n "implements" relation needed by the algorithm.
+	// m:n "implements" relation needed by the algorithm. Both sides are
+	// updated whenever a new type is seen, so the relation never depends
+	// on the order in which concrete types and interfaces are discovered.
 
-	// concreteTypes maps each concrete type to information about it.
+	// concreteTypes maps each runtime type to information about it.
 	// Keys are types.Type, values are *concreteTypeInfo.
-	// Only concrete types used as MakeInterface operands are included.
+	// Aliases map to the same info as the type they denote. Invoke sites
+	// get an edge to each of them, so it must not hold candidate
+	// implementations that were never converted to an interface.
 	concreteTypes typeutil.Map
 
 	// interfaceTypes maps each interface type to information about it.
 	// Keys are *types.Interface, values are *interfaceTypeInfo.
-	// Only interfaces used in "invoke"-mode CallInstructions are included.
 	interfaceTypes typeutil.Map
 
-	// Pre-computed interface implementation relationships for O(1) lookups.
-	// Built lazily on first use and updated incrementally as new types are discovered.
-	interfaceToTypes map[*types.Interface][]types.Type // interface -> implementing types
-	typeToInterfaces map[types.Type][]*types.Interface // type -> implemented interfaces
+	// convertedTo maps each concrete type to the interfaces it was
+	// converted to, with Options.PreciseUnnamedInterfaces.
+	// Keys are types.Type, values are []*types.Interface.
+	convertedTo typeutil.Map
 
-	// Comprehensive type index for user code - built once to avoid repeated scanning.
+	// reflectionMarked contains the runtime types whose exported methods
+	// have all been marked reachable for reflection.
+	reflectionMarked typeutil.Map
+
+	// Index of candidate implementations for type assertions and interface
+	// conversions, built on first use. interfaceToTypes is keyed by the
+	// canonical interfaceTypeInfo.I and filled lazily per interface; every
+	// type added to userTypes afterwards is checked against the indexed
+	// interfaces, so an entry is complete no matter when it was created.
 	userTypesIndexBuilt bool
-	userTypes           []types.Type // All types from user packages (non-stdlib)
+	userTypes           []types.Type                      // user package types and runtime types
+	userTypeSeen        typeutil.Map                      // set of userTypes
+	userTypeFprints     typeutil.Map                      // method set fingerprints of userTypes and their pointers
+	interfaceToTypes    map[*types.Interface][]types.Type // interface -> implementing types
+	indexedInterfaces   []*interfaceTypeInfo              // keys of interfaceToTypes, in insertion order
 }
 
 type concreteTypeInfo struct {
@@ -240,7 +340,13 @@ type interfaceTypeInfo struct {
 	mset            *types.MethodSet
 	fprint          uint64
 	implementations []types.Type // unordered set of concrete implementations
-	computed        bool         // whether implementations have been computed
+
+	// assertedByUser is set once user code asserts or converts to I: the
+	// interface methods of every indexed implementation are then reachable.
+	assertedByUser bool
+	// assertedByStdlib is set once stdlib code asserts to I: the interface
+	// methods of indexed implementations are reachable if they are runtime types.
+	assertedByStdlib bool
 }
 
 // markGenericTemplateReachable checks if a function is an instantiated generic
@@ -271,6 +377,12 @@ func (r *rta) addReachable(f *ssa.Function, addrTaken bool) {
 	if f == nil {
 		return // Don't add nil functions to the worklist
 	}
+	if obj := f.Object(); obj != nil && (r.opts.Removed[obj] || r.opts.RemovedPkgs[obj.Pkg()]) {
+		return
+	}
+	if f.Pkg != nil && r.opts.RemovedPkgs[f.Pkg.Pkg] {
+		return
+	}
 
 	reachable := r.result.Reachable
 	n := len(reachable)
@@ -292,7 +404,7 @@ func (r *rta) addReachable(f *ssa.Function, addrTaken bool) {
 // addReachableObject marks a types.Object as reachable even when there's no SSA function.
 // This handles generic template methods that exist in the type system but not in SSA.
 func (r *rta) addReachableObject(obj types.Object) {
-	if obj != nil {
+	if obj != nil && !r.opts.Removed[obj] && !r.opts.RemovedPkgs[obj.Pkg()] {
 		r.result.ReachableObjects[obj] = true
 	}
 }
@@ -370,6 +482,59 @@ func (r *rta) visitDynCall(site ssa.CallInstruction) {
 	}
 }
 
+// ---------- package-level variables ----------
+
+// unreadGlobalSink returns the unexported package-level variable that instr
+// stores function values into, directly or by filling a map the variable is
+// then set to, if reachable code has not read the variable yet.
+func (r *rta) unreadGlobalSink(instr ssa.Instruction) *ssa.Global {
+	var g *ssa.Global
+	switch instr := instr.(type) {
+	case *ssa.Store:
+		g, _ = instr.Addr.(*ssa.Global)
+	case *ssa.MapUpdate:
+		m, ok := instr.Map.(*ssa.MakeMap)
+		if !ok {
+			return nil
+		}
+		// The map must not escape anywhere but into the variable.
+		for _, ref := range *m.Referrers() {
+			switch ref := ref.(type) {
+			case *ssa.MapUpdate:
+				if ref.Map != m {
+					return nil
+				}
+			case *ssa.Store:
+				if ref.Val != m || g != nil {
+					return nil
+				}
+				if g, _ = ref.Addr.(*ssa.Global); g == nil {
+					return nil
+				}
+			default:
+				return nil
+			}
+		}
+	}
+	if g == nil || g.Object() == nil || g.Object().Exported() || r.readGlobals[g] {
+		return nil
+	}
+	return g
+}
+
+// readGlobal records that reachable code reads g, making the functions
+// stored into it address-taken.
+func (r *rta) readGlobal(g *ssa.Global) {
+	if r.readGlobals[g] {
+		return
+	}
+	r.readGlobals[g] = true
+	for _, f := range r.pendingGlobalFuncs[g] {
+		r.visitAddrTakenFunc(f)
+	}
+	delete(r.pendingGlobalFuncs, g)
+}
+
 // ---------- concrete types × invoke sites ----------
 
 // addInvokeEdge is called for each new pair (site, C) in the matrix.
@@ -378,6 +543,12 @@ func (r *rta) addInvokeEdge(site ssa.CallInstruction, C types.Type) {
 	// Example: `type PathError = os.PathError` requires resolving to os.PathError
 	// where the methods are actually defined. Without Unalias, method lookups would fail.
 	C = types.Unalias(C)
+	if r.excludedImpl(site.Common().Value.Type().Underlying().(*types.Interface), C) {
+		return
+	}
+	if I, unnamed := types.Unalias(site.Common().Value.Type()).(*types.Interface); unnamed && r.opts.PreciseUnnamedInterfaces && !r.converted(C, I) {
+		return
+	}
 
 	// Ascertain the concrete method of C to be called.
 	// For interface methods, the actual implementation could be on either the value or pointer.
@@ -476,10 +647,21 @@ func (r *rta) visitFunc(f *ssa.Function) {
 				r.handleChangeInterface(instr)
 			}
 
-			// Process all address-taken functions.
+			// Process all address-taken functions, and the package-level
+			// variables read.
+			sink := r.unreadGlobalSink(instr)
 			for _, op := range rands {
-				if g, ok := (*op).(*ssa.Function); ok {
-					r.visitAddrTakenFunc(g)
+				switch v := (*op).(type) {
+				case *ssa.Function:
+					if sink != nil {
+						r.pendingGlobalFuncs[sink] = append(r.pendingGlobalFuncs[sink], v)
+					} else {
+						r.visitAddrTakenFunc(v)
+					}
+				case *ssa.Global:
+					if store, ok := instr.(*ssa.Store); !ok || op != &store.Addr {
+						r.readGlobal(v)
+					}
 				}
 			}
 		}
@@ -496,7 +678,10 @@ func (r *rta) visitFunc(f *ssa.Function) {
 //
 // This fork reduces false positives from JSON encoding and fmt printing patterns.
 // through optimized reflection handling.
-func Analyze(roots []*ssa.Function) *Result {
+//
+// If opts.MaxVisits is reached first, a warning is logged and the partial
+// result is returned with Truncated set.
+func Analyze(roots []*ssa.Function, opts Options) *Result {
 	if len(roots) == 0 {
 		return nil
 	}
@@ -506,7 +691,10 @@ func Analyze(roots []*ssa.Function) *Result {
 			Reachable:        make(map[*ssa.Function]struct{ AddrTaken bool }),
 			ReachableObjects: make(map[types.Object]bool),
 		},
-		prog: roots[0].Prog,
+		prog:               roots[0].Prog,
+		opts:               opts,
+		readGlobals:        make(map[*ssa.Global]bool),
+		pendingGlobalFuncs: make(map[*ssa.Global][]*ssa.Function),
 	}
 
 	// Grab ssa.Function for (*reflect.Value).Call,
@@ -523,6 +711,10 @@ func Analyze(roots []*ssa.Function) *Result {
 	r.invokeSites.SetHasher(hasher)
 	r.concreteTypes.SetHasher(hasher)
 	r.interfaceTypes.SetHasher(hasher)
+	r.reflectionMarked.SetHasher(hasher)
+	r.convertedTo.SetHasher(hasher)
+	r.userTypeSeen.SetHasher(hasher)
+	r.userTypeFprints.SetHasher(hasher)
 
 	const initialWorklistCap = 2048
 	r.worklist = make([]*ssa.Function, 0, initialWorklistCap)
@@ -530,6 +722,12 @@ func Analyze(roots []*ssa.Function) *Result {
 	for _, root := range roots {
 		r.addReachable(root, false)
 	}
+	for _, iface := range opts.AssumeInvoked {
+		r.assertInterface(iface, false)
+	}
+	for _, conv := range opts.Conversions {
+		r.addRuntimeTypeForInterface(conv.T, conv.Interface, false)
+	}
 
 	// Visit functions, processing their instructions, and adding.
 	// new functions to the worklist, until a fixed point is
@@ -540,77 +738,48 @@ func Analyze(roots []*ssa.Function) *Result {
 	// append operations reuse the underlying array without new allocations.
 	// Benchmarks show this reduces allocation rate by ~30% on large codebases.
 	shadow := make([]*ssa.Function, 0, initialWorklistCap)
+	var recent [recentVisits]*ssa.Function
+	visits := 0
 	for len(r.worklist) > 0 {
 		shadow, r.worklist = r.worklist, shadow[:0]
-		for _, f := range shadow {
+		for i, f := range shadow {
+			if opts.MaxVisits > 0 && visits >= opts.MaxVisits {
+				r.result.Truncated = true
+				r.result.Implementations = r.interfaceToTypes
+				slog.Warn("RTA visit limit reached, returning partial results",
+					"visits", visits,
+					"worklist", len(shadow)-i+len(r.worklist),
+					"last", recentFunctions(recent[:], visits))
+				return r.result
+			}
 			r.visitFunc(f)
+			recent[visits%recentVisits] = f
+			visits++
 		}
 	}
+	r.result.Implementations = r.interfaceToTypes
 	return r.result
 }
 
-// interfaces(C) returns all currently known interfaces implemented by C.
-func (r *rta) interfaces(C types.Type) []*types.Interface {
-	// Get or create cached info for C.
-	cinfo := r.getConcreteTypeInfo(C)
-
-	// If this is the first time we see C, update the implements relation.
-	if len(cinfo.implements) == 0 {
-		// Ascertain set of interfaces C implements.
-		r.interfaceTypes.Iterate(func(I types.Type, v any) {
-			iinfo := v.(*interfaceTypeInfo)
-			iface := types.Unalias(I).(*types.Interface)
-			if implements(cinfo, iinfo) {
-				cinfo.implements = append(cinfo.implements, iface)
-			}
-		})
+// recentFunctions returns the names of the functions in the ring buffer
+// recent after visits visits, oldest first.
+func recentFunctions(recent []*ssa.Function, visits int) []string {
+	var names []string
+	for i := max(0, visits-len(recent)); i < visits; i++ {
+		names = append(names, recent[i%len(recent)].String())
 	}
+	return names
+}
 
-	return cinfo.implements
+// interfaces(C) returns all currently known interfaces implemented by C.
+func (r *rta) interfaces(C types.Type) []*types.Interface {
+	return r.getConcreteTypeInfo(C).implements
 }
 
 // implementations(I) returns all currently known concrete types that implement I.
 func (r *rta) implementations(I *types.Interface) []types.Type {
 	// Unalias interface for consistent lookups.
-	I = types.Unalias(I).(*types.Interface)
-
-	// Get or create cached info for I.
-	iinfo := r.getInterfaceTypeInfo(I)
-
-	// If this is the first time we see I, update the implements relation.
-	if !iinfo.computed {
-		if r.interfaceToTypes != nil {
-			if cached, exists := r.interfaceToTypes[I]; exists {
-				// Use pre-computed result and mark interface as computed.
-				iinfo.implementations = cached
-				iinfo.computed = true
-				return cached
-			}
-		}
-
-		// Fallback: Iterate over all concrete types to find implementations.
-		// This path is taken when the interface is seen before buildUserTypesIndex()
-		// is called (e.g., early in analysis via visitInvoke).
-		r.concreteTypes.Iterate(func(_ types.Type, vC any) {
-			cinfo := vC.(*concreteTypeInfo)
-			// Use cinfo.C which is always unaliased, not the map key.
-			C := cinfo.C
-
-			// Use implements() which has fingerprint optimization built-in.
-			// It checks value receiver fingerprint, then calls types.Implements.
-			if implements(cinfo, iinfo) {
-				cinfo.implements = append(cinfo.implements, I)
-				iinfo.implementations = append(iinfo.implementations, C)
-			}
-		})
-		iinfo.computed = true
-
-		// Cache the result in the global index for future lookups.
-		if r.interfaceToTypes != nil {
-			r.interfaceToTypes[I] = iinfo.implementations
-		}
-	}
-	return iinfo.implementations
+	return r.getInterfaceTypeInfo(types.Unalias(I).(*types.Interface)).implementations
 }
 
 // handleMakeInterface handles MakeInterface instructions with context awareness.
@@ -690,30 +859,40 @@ func (r *rta) handleTypeAssert(instr *ssa.TypeAssert) {
 		return // Not an interface assertion or empty interface.
 	}
 
-	// Unalias for consistent map key lookups.
-	iface = types.Unalias(iface).(*types.Interface)
-
-	// Build the user types index once on first type assertion.
-	r.buildUserTypesIndex()
-
 	// Only do comprehensive scanning for type assertions in user code.
 	// For stdlib code (like fmt.Printf's Stringer check), rely on RuntimeTypes only.
 	// This prevents marking unused String() methods as used just because they exist.
-	if r.isStdlibFunction(r.currentFunction) {
-		// For stdlib functions, only check types that are actually in RuntimeTypes.
-		// Use pre-computed interface index for fast lookup.
-		for _, T := range r.interfaceToTypes[iface] {
+	// Either way the assertion is remembered, so implementations discovered
+	// later are treated exactly like those already known.
+	r.assertInterface(iface, r.isStdlibFunction(r.currentFunction))
+}
+
+// assertInterface records that values may be asserted or converted to iface
+// and marks the interface methods of every known implementation reachable.
+// If stdlib is set, only implementations that are runtime types are marked.
+func (r *rta) assertInterface(iface *types.Interface, stdlib bool) {
+	impls := r.findAllImplementationsInProgram(iface)
+	iinfo := r.getInterfaceTypeInfo(types.Unalias(iface).(*types.Interface))
+	if stdlib {
+		if iinfo.assertedByStdlib || iinfo.assertedByUser {
+			return // Already marked, and future types are handled by addUserType.
+		}
+		iinfo.assertedByStdlib = true
+		for _, T := range impls {
 			// Only mark types that are actually in RuntimeTypes (not just user types).
 			if _, inRuntime := r.result.RuntimeTypes.At(T).(bool); inRuntime {
-				r.markInterfaceMethodsReachable(T, iface)
+				r.markInterfaceMethodsReachable(T, iinfo.I)
 			}
 		}
 		return
 	}
 
-	// For user code, use comprehensive pre-computed implementation map.
-	for _, T := range r.findAllImplementationsInProgram(iface) {
-		r.markInterfaceMethodsReachable(T, iface)
+	if iinfo.assertedByUser {
+		return
+	}
+	iinfo.assertedByUser = true
+	for _, T := range impls {
+		r.markInterfaceMethodsReachable(T, iinfo.I)
 	}
 }
 
@@ -752,28 +931,28 @@ func hasModulePath(path string) bool {
 	return false // No slash and no dot = stdlib (e.g., "fmt")
 }
 
-// buildUserTypesIndex builds a one-time index of all user types and pre-computes
-// which types implement which interfaces. This is called once on first use to avoid
-// repeated expensive scanning and N×M type checking.
+// buildUserTypesIndex builds a one-time index of all user types. Interfaces are
+// indexed lazily by findAllImplementationsInProgram, and types discovered later
+// are added by addTypeToIndex.
 func (r *rta) buildUserTypesIndex() {
 	if r.userTypesIndexBuilt {
 		return
 	}
-
-	seen := make(map[types.Type]bool)
+	r.userTypesIndexBuilt = true
+	r.interfaceToTypes = make(map[*types.Interface][]types.Type)
 
 	// Collect all types from RuntimeTypes first.
 	r.result.RuntimeTypes.Iterate(func(T types.Type, _ any) {
-		if _, isIface := T.Underlying().(*types.Interface); !isIface {
-			if !seen[T] {
-				r.userTypes = append(r.userTypes, T)
-				seen[T] = true
-			}
-		}
+		r.addUserType(T)
 	})
 
-	// Scan all user packages (non-stdlib) for types.
-	for _, pkg := range r.prog.AllPackages() {
+	// Scan all user packages (non-stdlib) for types. Packages and members are
+	// visited in a fixed order so that userTypes is the same on every run.
+	pkgs := r.prog.AllPackages()
+	slices.SortFunc(pkgs, func(a, b *ssa.Package) int {
+		return strings.Compare(a.Pkg.Path(), b.Pkg.Path())
+	})
+	for _, pkg := range pkgs {
 		if pkg == nil || pkg.Pkg == nil {
 			continue
 		}
@@ -784,85 +963,99 @@ func (r *rta) buildUserTypesIndex() {
 		}
 
 		// Collect all named types in the package.
-		for _, member := range pkg.Members {
-			typeName, ok := member.(*ssa.Type)
-			if !ok {
-				continue
-			}
-
-			T := typeName.Object().Type()
-
-			// Skip interfaces.
-			if _, isIface := T.Underlying().(*types.Interface); isIface {
-				continue
-			}
-
-			// Skip if already added.
-			if !seen[T] {
-				r.userTypes = append(r.userTypes, T)
-				seen[T] = true
+		for _, name := range slices.Sorted(maps.Keys(pkg.Members)) {
+			if typeName, ok := pkg.Members[name].(*ssa.Type); ok {
+				r.addUserType(typeName.Object().Type())
 			}
 		}
 	}
+}
 
-	// Pre-compute concrete type info for all user types to avoid repeated.
-	// expensive typeutil.Map lookups in the hot path
-	for _, T := range r.userTypes {
-		r.getConcreteTypeInfo(T)                   // Cache value receiver methods
-		r.getConcreteTypeInfo(types.NewPointer(T)) // Cache pointer receiver methods
+// addUserType adds T to the candidate implementations used for type
+// assertions and interface conversions, checking it against every interface
+// indexed so far.
+func (r *rta) addUserType(T types.Type) {
+	T = types.Unalias(T)
+	if _, isIface := T.Underlying().(*types.Interface); isIface {
+		return
 	}
-
-	// Initialize the interface implementation maps.
-	if r.interfaceToTypes == nil {
-		r.interfaceToTypes = make(map[*types.Interface][]types.Type)
-		r.typeToInterfaces = make(map[types.Type][]*types.Interface)
+	if r.userTypeSeen.At(T) != nil {
+		return
 	}
+	r.userTypeSeen.Set(T, true)
+	r.userTypes = append(r.userTypes, T)
 
-	// Pre-compute which user types implement which interfaces (N×M done once).
-	// Build interface info cache for fast fingerprint checking.
-	iinfoCache := make(map[*types.Interface]*interfaceTypeInfo)
-	r.interfaceTypes.Iterate(func(I types.Type, v any) {
-		iface := types.Unalias(I).(*types.Interface)
-		iinfo := v.(*interfaceTypeInfo)
-		iinfoCache[iface] = iinfo
-	})
-
-	// Check each user type against each interface ONCE.
-	for _, T := range r.userTypes {
-		valueInfo := r.getConcreteTypeInfo(T)
-		ptrInfo := r.getConcreteTypeInfo(types.NewPointer(T))
-
-		for iface, iinfo := range iinfoCache {
-			// Fast fingerprint rejection for both value and pointer receivers.
-			valueFingerprintMatches := iinfo.fprint&^valueInfo.fprint == 0
-			ptrFingerprintMatches := iinfo.fprint&^ptrInfo.fprint == 0
-
-			if !valueFingerprintMatches && !ptrFingerprintMatches {
-				continue
-			}
-
-			// Full implementation check.
-			if types.Implements(T, iface) || types.Implements(types.NewPointer(T), iface) {
-				r.interfaceToTypes[iface] = append(r.interfaceToTypes[iface], T)
-				r.typeToInterfaces[T] = append(r.typeToInterfaces[T], iface)
+	for _, iinfo := range r.indexedInterfaces {
+		if !r.userTypeImplements(T, iinfo) {
+			continue
+		}
+		r.interfaceToTypes[iinfo.I] = append(r.interfaceToTypes[iinfo.I], T)
+		if iinfo.assertedByUser {
+			r.markInterfaceMethodsReachable(T, iinfo.I)
+		} else if iinfo.assertedByStdlib {
+			if _, inRuntime := r.result.RuntimeTypes.At(T).(bool); inRuntime {
+				r.markInterfaceMethodsReachable(T, iinfo.I)
 			}
 		}
 	}
+}
 
-	r.userTypesIndexBuilt = true
+// userTypeImplements reports whether T or *T implements the interface.
+func (r *rta) userTypeImplements(T types.Type, iinfo *interfaceTypeInfo) bool {
+	// Fast fingerprint rejection for both value and pointer receivers.
+	valueFingerprintMatches := iinfo.fprint&^r.userTypeFingerprint(T) == 0
+	ptrFingerprintMatches := iinfo.fprint&^r.userTypeFingerprint(types.NewPointer(T)) == 0
+	if !valueFingerprintMatches && !ptrFingerprintMatches {
+		return false
+	}
+
+	// Full implementation check.
+	return types.Implements(T, iinfo.I) || types.Implements(types.NewPointer(T), iinfo.I)
+}
+
+// userTypeFingerprint returns the fingerprint of the method set of T. It is
+// cached apart from concreteTypes: a user type is only a candidate
+// implementation, and must not take part in the invoke edges of runtime types.
+func (r *rta) userTypeFingerprint(T types.Type) uint64 {
+	if fprint, ok := r.userTypeFprints.At(T).(uint64); ok {
+		return fprint
+	}
+	fprint := Fingerprint(r.prog.MethodSets.MethodSet(T))
+	r.userTypeFprints.Set(T, fprint)
+	return fprint
 }
 
 // findAllImplementationsInProgram finds types that implement the given interface.
 func (r *rta) findAllImplementationsInProgram(iface *types.Interface) []types.Type {
-	// Unalias for consistent map key lookups.
-	iface = types.Unalias(iface).(*types.Interface)
 	r.buildUserTypesIndex()
-	return r.interfaceToTypes[iface]
+
+	// Identical interfaces share one entry, keyed by the canonical type.
+	iinfo := r.getInterfaceTypeInfo(types.Unalias(iface).(*types.Interface))
+	if impls, ok := r.interfaceToTypes[iinfo.I]; ok {
+		return impls
+	}
+
+	// First use of this interface: check it against all user types now.
+	// Types added later are checked by addUserType.
+	var impls []types.Type
+	for _, T := range r.userTypes {
+		if r.userTypeImplements(T, iinfo) {
+			impls = append(impls, T)
+		}
+	}
+	// Record the result even if empty so the scan happens once per interface.
+	r.interfaceToTypes[iinfo.I] = impls
+	r.indexedInterfaces = append(r.indexedInterfaces, iinfo)
+	return impls
 }
 
 // markInterfaceMethodsReachable marks all methods required by the interface
 // as reachable on the concrete type T.
 func (r *rta) markInterfaceMethodsReachable(T types.Type, iface *types.Interface) {
+	if r.excludedImpl(iface, T) {
+		return
+	}
+
 	// Get method sets for both value and pointer receivers.
 	valueMset := r.prog.MethodSets.MethodSet(T)
 	ptrMset := r.prog.MethodSets.MethodSet(types.NewPointer(T))
@@ -886,6 +1079,32 @@ func (r *rta) markInterfaceMethodsReachable(T types.Type, iface *types.Interface
 	}
 }
 
+// converted reports whether C was converted to an interface with the same
+// methods as I. Such conversions mark the methods of I on C reachable.
+func (r *rta) converted(C types.Type, I *types.Interface) bool {
+	ifaces, _ := r.convertedTo.At(C).([]*types.Interface)
+	for _, iface := range ifaces {
+		if types.Identical(iface, I) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludedImpl reports whether Options.AssumeImpl rules out C, or *C, as the
+// dynamic type of values of interface I.
+func (r *rta) excludedImpl(I *types.Interface, C types.Type) bool {
+	assumed, ok := r.opts.AssumeImpl[I]
+	if !ok {
+		return false
+	}
+	C = types.Unalias(C)
+	if ptr, ok := C.(*types.Pointer); ok {
+		C = types.Unalias(ptr.Elem())
+	}
+	return !types.Identical(C, types.Unalias(assumed))
+}
+
 // checkSetFinalizer checks if a call is to runtime.SetFinalizer and marks the finalizer function as reachable.
 // Finalizers are called by the garbage collector, not through normal program flow.
 func (r *rta) checkSetFinalizer(call *ssa.CallCommon) {
@@ -941,9 +1160,7 @@ func (r *rta) handleChangeInterface(instr *ssa.ChangeInterface) {
 	// Find ALL types in the program that implement this interface.
 	// This includes test-only types that may never be in RuntimeTypes.
 	// Mark all interface methods as reachable on each implementing type.
-	for _, T := range r.findAllImplementationsInProgram(targetIface) {
-		r.markInterfaceMethodsReachable(T, targetIface)
-	}
+	r.assertInterface(targetIface, false)
 }
 
 // markImplementorsMethodsReachable marks all methods of concrete types that implement
@@ -1039,6 +1256,11 @@ func (r *rta) addRuntimeTypeForInterface(T types.Type, iface *types.Interface, s
 	_, alreadyInRuntimeTypes := r.result.RuntimeTypes.At(T).(bool)
 	if !alreadyInRuntimeTypes {
 		r.result.RuntimeTypes.Set(T, skip)
+		r.addTypeToIndex(T)
+	}
+	if r.opts.PreciseUnnamedInterfaces && !r.converted(T, iface) {
+		ifaces, _ := r.convertedTo.At(T).([]*types.Interface)
+		r.convertedTo.Set(T, append(ifaces, iface))
 	}
 
 	mset := r.prog.MethodSets.MethodSet(T)
@@ -1047,21 +1269,24 @@ func (r *rta) addRuntimeTypeForInterface(T types.Type, iface *types.Interface, s
 		// T is a new concrete type.
 		// Always mark methods required by the interface, even if the type was seen before.
 		// (it might have been added for a different interface)
-		for i := range iface.NumMethods() {
-			method := iface.Method(i)
-			// Look up the corresponding method in the concrete type.
-			// Pass the method's package to find unexported methods (marker methods like isValidator()).
-			// Passing nil would only find exported methods, which would miss unexported marker methods.
-			sel := mset.Lookup(method.Pkg(), method.Name())
-			// Mark ALL methods required by the interface, including unexported marker methods.
-			// Marker methods (like isValidator()) exist solely for interface satisfaction.
-			// and must be marked as used even though they're never called directly.
-			if sel != nil {
-				if fn := r.prog.MethodValue(sel); fn != nil {
-					r.addReachable(fn, true)
-				} else if sel.Obj() != nil {
-					// No SSA function (generic template method), track by Object.
-					r.addReachableObject(sel.Obj())
+		// Under Options.AssumeImpl, T may be ruled out as a value of iface.
+		if !r.excludedImpl(iface, T) {
+			for i := range iface.NumMethods() {
+				method := iface.Method(i)
+				// Look up the corresponding method in the concrete type.
+				// Pass the method's package to find unexported methods (marker methods like isValidator()).
+				// Passing nil would only find exported methods, which would miss unexported marker methods.
+				sel := mset.Lookup(method.Pkg(), method.Name())
+				// Mark ALL methods required by the interface, including unexported marker methods.
+				// Marker methods (like isValidator()) exist solely for interface satisfaction.
+				// and must be marked as used even though they're never called directly.
+				if sel != nil {
+					if fn := r.prog.MethodValue(sel); fn != nil {
+						r.addReachable(fn, true)
+					} else if sel.Obj() != nil {
+						// No SSA function (generic template method), track by Object.
+						r.addReachableObject(sel.Obj())
+					}
 				}
 			}
 		}
@@ -1128,6 +1353,7 @@ func (r *rta) addRuntimeTypeSelective(T types.Type, skip bool) {
 		return
 	}
 	r.result.RuntimeTypes.Set(T, skip)
+	r.addTypeToIndex(T)
 
 	mset := r.prog.MethodSets.MethodSet(T)
 
@@ -1196,7 +1422,13 @@ func (r *rta) addRuntimeType(T types.Type, skip bool) {
 		if skip && !prev {
 			r.result.RuntimeTypes.Set(T, skip)
 		}
-		return
+		// A type recorded by addRuntimeTypeForInterface, addRuntimeTypeSelective
+		// or in a known safe context only had some of its methods marked. Seeing
+		// it again where reflection is unrestricted must mark the rest, or the
+		// result would depend on which conversion happened to be visited first.
+		if skip || r.reflectionMarked.At(T) != nil || r.isInKnownSafeContext() {
+			return
+		}
 	}
 	r.result.RuntimeTypes.Set(T, skip)
 
@@ -1270,6 +1502,7 @@ func (r *rta) addRuntimeType(T types.Type, skip bool) {
 	// Workaround: Users should add suppression comments for template methods:
 	//   //nolint:unusedfunc // used in template.gotmpl:15
 	if !skip {
+		r.reflectionMarked.Set(T, true)
 		for i := range mset.Len() {
 			sel := mset.At(i)
 			m := sel.Obj()
@@ -1425,6 +1658,22 @@ func (r *rta) getConcreteTypeInfo(C types.Type) *concreteTypeInfo {
 		r.concreteTypes.Set(origC, cinfo)
 	}
 
+	// Update the implements relation against all known interfaces, and add
+	// edges from their existing invoke sites, exactly as if C had been known
+	// before those sites were visited.
+	r.interfaceTypes.Iterate(func(_ types.Type, v any) {
+		iinfo := v.(*interfaceTypeInfo)
+		if !implements(cinfo, iinfo) {
+			return
+		}
+		cinfo.implements = append(cinfo.implements, iinfo.I)
+		iinfo.implementations = append(iinfo.implementations, C)
+		sites, _ := r.invokeSites.At(iinfo.I).([]ssa.CallInstruction)
+		for _, site := range sites {
+			r.addInvokeEdge(site, C)
+		}
+	})
+
 	return cinfo
 }
 
@@ -1442,38 +1691,39 @@ func (r *rta) getInterfaceTypeInfo(I *types.Interface) *interfaceTypeInfo {
 		fprint: Fingerprint(mset),
 	}
 	r.interfaceTypes.Set(I, iinfo)
+
+	// Update the implements relation against all known concrete types.
+	r.concreteTypes.Iterate(func(C types.Type, v any) {
+		// Aliases share the info of the type they denote; count it once.
+		if _, isAlias := C.(*types.Alias); isAlias {
+			return
+		}
+		cinfo := v.(*concreteTypeInfo)
+		if implements(cinfo, iinfo) {
+			cinfo.implements = append(cinfo.implements, I)
+			iinfo.implementations = append(iinfo.implementations, cinfo.C)
+		}
+	})
 	return iinfo
 }
 
 // addTypeToIndex updates the interface index when a new runtime type is discovered.
 func (r *rta) addTypeToIndex(T types.Type) {
 	// Skip if index hasn't been built yet (will be included when built)
-	if r.interfaceToTypes == nil {
+	if !r.userTypesIndexBuilt {
 		return
 	}
 
-	// Skip interfaces.
-	if _, isIface := T.Underlying().(*types.Interface); isIface {
+	if r.userTypeSeen.At(types.Unalias(T)) == nil {
+		r.addUserType(T)
 		return
 	}
 
-	// Get cached concrete type info.
-	cinfo := r.getConcreteTypeInfo(T)
-
-	// Check against all known interfaces.
-	r.interfaceTypes.Iterate(func(I types.Type, v any) {
-		iface := types.Unalias(I).(*types.Interface)
-		iinfo := v.(*interfaceTypeInfo)
-
-		// Fast fingerprint rejection.
-		if iinfo.fprint&^cinfo.fprint != 0 {
-			return
+	// T is already indexed, but may have become a runtime type only now;
+	// stdlib assertions of the interfaces it implements apply to it.
+	for _, iinfo := range r.indexedInterfaces {
+		if iinfo.assertedByStdlib && !iinfo.assertedByUser && r.userTypeImplements(T, iinfo) {
+			r.markInterfaceMethodsReachable(T, iinfo.I)
 		}
-
-		// Full implementation check.
-		if types.Implements(T, iface) || types.Implements(types.NewPointer(T), iface) {
-			r.interfaceToTypes[iface] = append(r.interfaceToTypes[iface], T)
-			r.typeToInterfaces[T] = append(r.typeToInterfaces[T], iface)
-		}
-	})
+	}
 }
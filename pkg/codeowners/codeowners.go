@@ -0,0 +1,182 @@
+// Package codeowners parses GitHub CODEOWNERS files and resolves the owners
+// of individual files.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Rule is a single CODEOWNERS entry.
+type Rule struct {
+	// Pattern is the gitignore-style path pattern as written in the file.
+	Pattern string
+	// Owners are the users, teams or emails owning matching files.
+	// A rule without owners explicitly leaves matching files unowned.
+	Owners []string
+
+	re *regexp.Regexp
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	// Root is the directory patterns are relative to. When empty, filenames
+	// passed to Owners must already be relative to the repository root.
+	Root string
+
+	// Rules are kept in file order; later rules take precedence.
+	Rules []Rule
+}
+
+// Load reads and parses the CODEOWNERS file at path. The repository root is
+// inferred from the file location: files in .github/ or docs/ refer to their
+// parent directory, any other location refers to its own directory.
+func Load(path string) (*Ruleset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open codeowners: %w", err)
+	}
+	defer f.Close()
+
+	rs, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve codeowners path: %w", err)
+	}
+	root := filepath.Dir(abs)
+	if base := filepath.Base(root); base == ".github" || base == "docs" {
+		root = filepath.Dir(root)
+	}
+	rs.Root = root
+	return rs, nil
+}
+
+// Parse parses CODEOWNERS content.
+func Parse(r io.Reader) (*Ruleset, error) {
+	rs := &Ruleset{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		var owners []string
+		for _, owner := range fields[1:] {
+			// Trailing comments end the owner list.
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+
+		re, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		rs.Rules = append(rs.Rules, Rule{
+			Pattern: fields[0],
+			Owners:  owners,
+			re:      re,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading codeowners: %w", err)
+	}
+	return rs, nil
+}
+
+// Owners returns the owners of filename according to the most specific
+// matching rule. Following GitHub semantics, rules are ordered from general
+// to specific, so the last matching rule wins. Absolute filenames are made
+// relative to Root. Nil is returned when no rule matches or the file lies
+// outside the repository.
+func (rs *Ruleset) Owners(filename string) []string {
+	rel := filename
+	if filepath.IsAbs(filename) && rs.Root != "" {
+		var err error
+		rel, err = filepath.Rel(rs.Root, filename)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil
+		}
+	}
+	rel = filepath.ToSlash(rel)
+
+	for i := len(rs.Rules) - 1; i >= 0; i-- {
+		if rs.Rules[i].re.MatchString(rel) {
+			return rs.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// compilePattern converts a gitignore-style CODEOWNERS pattern to a regular
+// expression matching slash-separated paths relative to the repository root.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	p := pattern
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+
+	// Patterns containing a slash other than a trailing one are relative to
+	// the root; bare names match at any depth.
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	// A wildcard in the last path element matches files only: "docs/*"
+	// owns docs/a.md but not docs/sub/a.md, as "*" never crosses a slash.
+	last := p[strings.LastIndexByte(p, '/')+1:]
+	wildcard := strings.ContainsAny(last, "*?")
+
+	var b strings.Builder
+	b.WriteByte('^')
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				i++
+				if i+1 < len(p) && p[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	// A pattern matching a directory owns everything below it.
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case wildcard:
+		b.WriteByte('$')
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,133 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleset_Owners(t *testing.T) {
+	const content = `# Default owners
+*       @org/everyone
+
+*.md    @org/docs   # trailing comment
+/build/ @org/release
+apps/   @org/apps
+/internal/**/pool.go @org/pool
+/pkg/ssa/
+/pkg/legacy @alice bob@example.com
+`
+
+	rs, err := Parse(strings.NewReader(content))
+	require.NoError(t, err)
+	require.Len(t, rs.Rules, 7)
+
+	tests := []struct {
+		name     string
+		filename string
+		want     []string
+	}{
+		{
+			name:     "catch_all",
+			filename: "cmd/unusedfunc/main.go",
+			want:     []string{"@org/everyone"},
+		},
+		{
+			name:     "extension_at_any_depth",
+			filename: "docs/reference/guide.md",
+			want:     []string{"@org/docs"},
+		},
+		{
+			name:     "anchored_directory",
+			filename: "build/scripts/release.sh",
+			want:     []string{"@org/release"},
+		},
+		{
+			name:     "anchored_directory_not_nested",
+			filename: "tools/build/release.sh",
+			want:     []string{"@org/everyone"},
+		},
+		{
+			name:     "unanchored_directory",
+			filename: "services/apps/web/main.go",
+			want:     []string{"@org/apps"},
+		},
+		{
+			name:     "double_star",
+			filename: "internal/a/b/pool.go",
+			want:     []string{"@org/pool"},
+		},
+		{
+			name:     "double_star_zero_dirs",
+			filename: "internal/pool.go",
+			want:     []string{"@org/pool"},
+		},
+		{
+			name:     "rule_without_owners",
+			filename: "pkg/ssa/analyzer.go",
+			want:     nil,
+		},
+		{
+			name:     "multiple_owners",
+			filename: "pkg/legacy/old.go",
+			want:     []string{"@alice", "bob@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, rs.Owners(tt.filename))
+		})
+	}
+}
+
+func TestRuleset_OwnersNoMatch(t *testing.T) {
+	rs, err := Parse(strings.NewReader("/cmd/ @org/cli\n"))
+	require.NoError(t, err)
+	require.Nil(t, rs.Owners("pkg/ssa/analyzer.go"))
+}
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"docs/*", "docs/getting-started.md", true},
+		{"docs/*", "docs/build-app/troubleshooting.md", false},
+		{"docs/*", "src/docs/guide.md", false},
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/ssa/analyzer.go", true},
+		{"*.go", "main.go.orig", false},
+		{"/build/logs/", "build/logs/app.log", true},
+		{"/build/logs/", "build/logs/2024/app.log", true},
+		{"/build/logs/", "build/logs", false},
+		{"/build/logs/", "tools/build/logs/app.log", false},
+		{"/pkg/legacy", "pkg/legacy/old.go", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.path, func(t *testing.T) {
+			re, err := compilePattern(tt.pattern)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, re.MatchString(tt.path))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0o750))
+	path := filepath.Join(root, ".github", "CODEOWNERS")
+	require.NoError(t, os.WriteFile(path, []byte("/pkg/ @org/pkg\n"), 0o600))
+
+	rs, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, root, rs.Root)
+
+	require.Equal(t, []string{"@org/pkg"}, rs.Owners(filepath.Join(root, "pkg", "a.go")))
+	require.Nil(t, rs.Owners(filepath.Join(filepath.Dir(root), "elsewhere.go")), "files outside the root are unowned")
+}
@@ -0,0 +1,81 @@
+package codeowners
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrefixMap assigns files to teams by path prefix, a simpler alternative to
+// CODEOWNERS for repositories that have none.
+type PrefixMap struct {
+	// Root is the directory prefixes are relative to. When empty, filenames
+	// passed to Owners must already be relative to it.
+	Root string
+
+	// Teams maps slash-separated path prefixes to the team owning the files
+	// below them. A prefix matches whole path elements: "pkg/api" matches
+	// "pkg/api/server.go" but not "pkg/apiv2/server.go". The empty prefix,
+	// or "/", matches every file.
+	Teams map[string]string
+}
+
+// LoadPrefixMap reads a JSON or YAML object mapping path prefixes to teams
+// from the file at path, such as
+//
+//	{"pkg/api": "api-team", "pkg/api/internal": "platform"}
+//
+// Prefixes are relative to the directory of the file.
+func LoadPrefixMap(path string) (*PrefixMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open owners map: %w", err)
+	}
+
+	// YAML is a superset of JSON, so one decoder reads both.
+	var teams map[string]string
+	if err := yaml.Unmarshal(data, &teams); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve owners map path: %w", err)
+	}
+	return &PrefixMap{Root: filepath.Dir(abs), Teams: teams}, nil
+}
+
+// Owners returns the team of the longest prefix matching filename, or nil
+// if none does or the file lies outside Root. Absolute filenames are made
+// relative to Root.
+func (m *PrefixMap) Owners(filename string) []string {
+	rel := filename
+	if filepath.IsAbs(filename) && m.Root != "" {
+		var err error
+		rel, err = filepath.Rel(m.Root, filename)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil
+		}
+	}
+	rel = filepath.ToSlash(rel)
+
+	best, team := -1, ""
+	for prefix, t := range m.Teams {
+		p := strings.Trim(prefix, "/")
+		if p != "" && rel != p && !strings.HasPrefix(rel, p+"/") {
+			continue
+		}
+		// Ties between prefixes that trim to the same path are broken by
+		// team name, so that the result does not depend on map order.
+		if len(p) > best || len(p) == best && t < team {
+			best, team = len(p), t
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	return []string{team}
+}
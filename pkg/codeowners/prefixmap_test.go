@@ -0,0 +1,61 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixMap_Owners(t *testing.T) {
+	m := &PrefixMap{Teams: map[string]string{
+		"/":                "everyone",
+		"pkg/api":          "api-team",
+		"pkg/api/internal": "platform",
+		"cmd/":             "cli",
+	}}
+
+	tests := []struct {
+		name     string
+		filename string
+		want     []string
+	}{
+		{name: "catch_all", filename: "docs/guide.md", want: []string{"everyone"}},
+		{name: "prefix", filename: "pkg/api/server.go", want: []string{"api-team"}},
+		{name: "longest_prefix_wins", filename: "pkg/api/internal/pool/pool.go", want: []string{"platform"}},
+		{name: "whole_path_elements", filename: "pkg/apiv2/server.go", want: []string{"everyone"}},
+		{name: "trailing_slash", filename: "cmd/unusedfunc/main.go", want: []string{"cli"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, m.Owners(tt.filename))
+		})
+	}
+
+	delete(m.Teams, "/")
+	require.Nil(t, m.Owners("docs/guide.md"))
+}
+
+func TestLoadPrefixMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "json", content: `{"pkg/": "pkg-team"}`},
+		{name: "yaml", content: "pkg/: pkg-team\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			path := filepath.Join(root, "owners.map")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			m, err := LoadPrefixMap(path)
+			require.NoError(t, err)
+			require.Equal(t, root, m.Root)
+			require.Equal(t, []string{"pkg-team"}, m.Owners(filepath.Join(root, "pkg", "a.go")))
+			require.Nil(t, m.Owners(filepath.Join(filepath.Dir(root), "elsewhere.go")), "files outside the root are unowned")
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"time"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// JSONFormatVersion is the version of the shape of JSON reports. Within a
+// version, fields are only added, never removed, renamed or given another
+// meaning; a change that breaks consumers increments it. See
+// docs/reference/json-format.md.
+const JSONFormatVersion = 1
+
+// CheckJSONFormatVersion returns an error unless reports can be written in
+// the given format version. Zero stands for JSONFormatVersion.
+func CheckJSONFormatVersion(version int) error {
+	if version != 0 && version != JSONFormatVersion {
+		return fmt.Errorf("unsupported JSON format version %d: want %d", version, JSONFormatVersion)
+	}
+	return nil
+}
+
+// jsonFormatter writes the findings and statistics as a JSON document.
+type jsonFormatter struct {
+	opts Options
+}
+
+type jOutput struct {
+	// FormatVersion is the JSONFormatVersion the document is written in.
+	// Reports written before it was introduced have none, and are version 1.
+	FormatVersion    int         `json:"format_version"`
+	UnusedFunctions  []jFunction `json:"unused_functions"`
+	RemovedFunctions []jFunction `json:"removed_functions,omitempty"`
+	// Types rolls up the unused methods by receiver type.
+	Types     []TypeRollup `json:"types,omitempty"`
+	Stats     any          `json:"stats"`
+	Version   string       `json:"version"`
+	Timestamp string       `json:"timestamp"`
+}
+
+type jFunction struct {
+	Name        string   `json:"name"`
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Column      int      `json:"column"`
+	Reason      string   `json:"reason"`
+	Suppressed  bool     `json:"suppressed"`
+	Package     string   `json:"package"`
+	PackageName string   `json:"package_name"`
+	Owners      []string `json:"owners,omitempty"`
+	// Instantiations counts the distinct instantiations of a generic function.
+	Instantiations int `json:"instantiations,omitempty"`
+	Lines          int `json:"lines,omitempty"`
+	// Receiver and ReceiverMethods name the type of a method and count its
+	// methods.
+	Receiver        string `json:"receiver,omitempty"`
+	ReceiverMethods int    `json:"receiver_methods,omitempty"`
+	// Confidence is "high" or "low"; Severity maps it to the "warning" or
+	// "note" levels of code scanning tools.
+	Confidence string `json:"confidence,omitempty"`
+	Severity   string `json:"severity,omitempty"`
+	// SupersededBy names the used function likely replacing this one.
+	SupersededBy string `json:"superseded_by,omitempty"`
+	// StrictOnly marks findings that only strict mode reports.
+	StrictOnly bool `json:"strict_only,omitempty"`
+	// RuleURL links to the documentation of Reason.
+	RuleURL string `json:"rule_url,omitempty"`
+}
+
+func (f *jsonFormatter) Format(result *Result, w io.Writer) error {
+	if err := CheckJSONFormatVersion(f.opts.FormatVersion); err != nil {
+		return err
+	}
+
+	functions := make([]jFunction, 0, len(result.UnusedFunctions))
+	for _, function := range result.UnusedFunctions {
+		jf := toJFunction(function)
+		if f.opts.HelpURLBase != "" {
+			jf.RuleURL = RuleURL(f.opts.HelpURLBase, function.Reason)
+		}
+		functions = append(functions, jf)
+	}
+	var removed []jFunction
+	for _, function := range result.Removed {
+		removed = append(removed, toJFunction(function))
+	}
+
+	data, err := json.MarshalIndent(jOutput{
+		FormatVersion:    JSONFormatVersion,
+		UnusedFunctions:  functions,
+		RemovedFunctions: removed,
+		Types:            TypeRollups(result.UnusedFunctions),
+		Stats:            result.Stats,
+		Version:          f.opts.Version,
+		Timestamp:        time.Now().UTC().Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling json output: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func toJFunction(function unusedfunc.UnusedFunction) jFunction {
+	return jFunction{
+		Name:            function.Name,
+		File:            function.Position.Filename,
+		Line:            function.Position.Line,
+		Column:          function.Position.Column,
+		Reason:          function.Reason,
+		Suppressed:      function.Suppressed,
+		Package:         function.Package,
+		PackageName:     function.PackageName,
+		Owners:          function.Owners,
+		Instantiations:  function.Instantiations,
+		Lines:           function.Lines,
+		Receiver:        function.Receiver,
+		ReceiverMethods: function.ReceiverMethods,
+		Confidence:      function.Confidence,
+		Severity:        severity(function.Confidence),
+		SupersededBy:    function.SupersededBy,
+		StrictOnly:      function.StrictOnly,
+	}
+}
+
+// severity returns the severity of a finding of the given confidence.
+func severity(confidence string) string {
+	switch confidence {
+	case unusedfunc.HighConfidence:
+		return "warning"
+	case unusedfunc.LowConfidence:
+		return "note"
+	}
+	return ""
+}
+
+// ReadJSON reads the findings of a report written by the json format.
+func ReadJSON(r io.Reader) ([]unusedfunc.UnusedFunction, error) {
+	var report jOutput
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("decode report: %w", err)
+	}
+	if report.FormatVersion > JSONFormatVersion {
+		return nil, fmt.Errorf("report format version %d is newer than the supported version %d", report.FormatVersion, JSONFormatVersion)
+	}
+
+	functions := make([]unusedfunc.UnusedFunction, 0, len(report.UnusedFunctions))
+	for _, f := range report.UnusedFunctions {
+		functions = append(functions, unusedfunc.UnusedFunction{
+			Name: f.Name,
+			Position: token.Position{
+				Filename: f.File,
+				Line:     f.Line,
+				Column:   f.Column,
+			},
+			Reason:          f.Reason,
+			Suppressed:      f.Suppressed,
+			Package:         f.Package,
+			PackageName:     f.PackageName,
+			Owners:          f.Owners,
+			Instantiations:  f.Instantiations,
+			Lines:           f.Lines,
+			Receiver:        f.Receiver,
+			ReceiverMethods: f.ReceiverMethods,
+			Confidence:      f.Confidence,
+			SupersededBy:    f.SupersededBy,
+			StrictOnly:      f.StrictOnly,
+		})
+	}
+	return functions, nil
+}
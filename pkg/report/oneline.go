@@ -0,0 +1,30 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// onelineFormatter writes a single summary line, for badges and
+// notifications.
+type onelineFormatter struct{}
+
+func (onelineFormatter) Format(result *Result, w io.Writer) error {
+	packages := make(map[string]bool)
+	for _, fn := range result.UnusedFunctions {
+		packages[fn.Package] = true
+	}
+	_, err := fmt.Fprintf(w, "unusedfunc: %s across %s (%d suppressed)\n",
+		plural(result.Stats.UnusedFunctions, "unused function"),
+		plural(len(packages), "package"),
+		result.Stats.SuppressedFunctions)
+	return err
+}
+
+// plural formats n followed by noun, pluralized with "s" unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,159 @@
+// Package report renders unusedfunc findings in the supported output formats.
+//
+// Formats are looked up by name in a registry, so programs embedding the
+// analyzer can register their own formatters next to the built-in "text",
+// "json", "oneline" and "vim" ones.
+package report
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// Result is the outcome of an analysis run.
+type Result struct {
+	UnusedFunctions []unusedfunc.UnusedFunction `json:"unused_functions"`
+	// Removed holds the findings of a previous report that are now fixed.
+	Removed []unusedfunc.UnusedFunction `json:"removed_functions,omitempty"`
+	Stats   Stats                       `json:"stats"`
+	// Packages lists the import paths of the analyzed packages, sorted.
+	Packages []string `json:"packages,omitempty"`
+}
+
+// Stats summarizes an analysis run.
+type Stats struct {
+	TotalFunctions      int           `json:"total_functions"`
+	UnusedFunctions     int           `json:"unused_functions"`
+	SuppressedFunctions int           `json:"suppressed_functions"`
+	AnalysisDuration    time.Duration `json:"analysis_duration"`
+	// EstimatedRemovableLines sums the declaration lines of the findings
+	// that are safe to delete: unexported functions, which nothing outside
+	// the analyzed packages can call.
+	EstimatedRemovableLines int `json:"estimated_removable_lines"`
+	// Truncated is set when reachability analysis stopped at its visit
+	// limit, so that some findings may be used functions.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// TypeRollup counts the unused methods of a type that has any, to point out
+// types that could be deleted whole.
+type TypeRollup struct {
+	Type          string `json:"type"`
+	UnusedMethods int    `json:"unused_methods"`
+	TotalMethods  int    `json:"total_methods"`
+}
+
+// AllUnused reports whether none of the methods of the type is used.
+func (t TypeRollup) AllUnused() bool {
+	return t.TotalMethods > 0 && t.UnusedMethods >= t.TotalMethods
+}
+
+// TypeRollups groups the unsuppressed findings that are methods by receiver
+// type, sorted by type. Findings sharing a declaration count once.
+func TypeRollups(functions []unusedfunc.UnusedFunction) []TypeRollup {
+	byType := make(map[string]*TypeRollup)
+	seen := make(map[token.Position]bool)
+	for _, fn := range functions {
+		if fn.Receiver == "" || fn.Suppressed || seen[fn.Position] {
+			continue
+		}
+		seen[fn.Position] = true
+		t, ok := byType[fn.Receiver]
+		if !ok {
+			t = &TypeRollup{Type: fn.Receiver, TotalMethods: fn.ReceiverMethods}
+			byType[fn.Receiver] = t
+		}
+		t.UnusedMethods++
+	}
+
+	rollups := make([]TypeRollup, 0, len(byType))
+	for _, name := range slices.Sorted(maps.Keys(byType)) {
+		rollups = append(rollups, *byType[name])
+	}
+	return rollups
+}
+
+// Formatter writes a Result in a particular output format.
+type Formatter interface {
+	Format(result *Result, w io.Writer) error
+}
+
+// Options configures a formatter. Formats ignore options they do not use.
+type Options struct {
+	Verbose       bool   // include reasons and other details
+	GroupByOwner  bool   // group findings by their owners
+	ShortPackage  bool   // show package names instead of import paths
+	Version       string // version of the tool producing the report
+	HelpURLBase   string // documentation of the reasons, linked per finding; empty for no links
+	FormatVersion int    // JSONFormatVersion of JSON reports; zero for the current one
+}
+
+// DefaultHelpURLBase documents the reasons findings are reported for, with a
+// section per reason.
+const DefaultHelpURLBase = "https://github.com/715d/unusedfunc/blob/main/docs/reference/reasons.md"
+
+// RuleURL returns the URL documenting reason: base followed by the anchor
+// GitHub generates for a heading reading reason, e.g.
+// "<base>#unexported-and-unused".
+func RuleURL(base, reason string) string {
+	var anchor strings.Builder
+	for _, r := range strings.ToLower(reason) {
+		switch {
+		case r == ' ':
+			anchor.WriteByte('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			anchor.WriteRune(r)
+		}
+	}
+	return base + "#" + anchor.String()
+}
+
+// Factory creates a Formatter configured with opts.
+type Factory func(opts Options) Formatter
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register makes a format available under name, replacing any format
+// previously registered under the same name.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = factory
+}
+
+// New returns the formatter registered under name.
+func New(name string, opts Options) (Formatter, error) {
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (available: %v)", name, Names())
+	}
+	return factory(opts), nil
+}
+
+// Names returns the registered format names in sorted order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Sorted(maps.Keys(factories))
+}
+
+func init() {
+	Register("text", func(opts Options) Formatter { return &textFormatter{opts: opts} })
+	Register("json", func(opts Options) Formatter { return &jsonFormatter{opts: opts} })
+	Register("oneline", func(Options) Formatter { return onelineFormatter{} })
+	Register("vim", func(Options) Formatter { return vimFormatter{} })
+}
@@ -0,0 +1,347 @@
+package report
+
+import (
+	"bytes"
+	"go/token"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+func testResult() *Result {
+	return &Result{
+		UnusedFunctions: []unusedfunc.UnusedFunction{
+			{
+				Name:        "example.com/a.helper",
+				Position:    token.Position{Filename: "a/a.go", Line: 3, Column: 6},
+				Reason:      "unexported and unused",
+				Package:     "example.com/a",
+				PackageName: "a",
+				Owners:      []string{"@org/a"},
+				Lines:       4,
+			},
+			{
+				Name:        "example.com/b.helper",
+				Position:    token.Position{Filename: "b/b.go", Line: 7, Column: 6},
+				Reason:      "unexported and unused",
+				Package:     "example.com/b",
+				PackageName: "b",
+			},
+		},
+		Stats: Stats{TotalFunctions: 10, UnusedFunctions: 2, EstimatedRemovableLines: 4},
+	}
+}
+
+func TestTextFormatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		removed  []unusedfunc.UnusedFunction
+		expected string
+	}{
+		{
+			name: "compact",
+			expected: "a/a.go:3:6 example.com/a.helper\n" +
+				"b/b.go:7:6 example.com/b.helper\n",
+		},
+		{
+			name: "verbose",
+			opts: Options{Verbose: true},
+			expected: "\nexample.com/a:\n" +
+				"  a/a.go:3:6 example.com/a.helper (unexported and unused)\n" +
+				"\nexample.com/b:\n" +
+				"  b/b.go:7:6 example.com/b.helper (unexported and unused)\n",
+		},
+		{
+			name: "verbose_short_package",
+			opts: Options{Verbose: true, ShortPackage: true},
+			expected: "\na:\n" +
+				"  a/a.go:3:6 example.com/a.helper (unexported and unused)\n" +
+				"\nb:\n" +
+				"  b/b.go:7:6 example.com/b.helper (unexported and unused)\n",
+		},
+		{
+			name: "group_by_owner",
+			opts: Options{GroupByOwner: true},
+			expected: "@org/a:\n" +
+				"  a/a.go:3:6 example.com/a.helper\n" +
+				"\n(unowned):\n" +
+				"  b/b.go:7:6 example.com/b.helper\n",
+		},
+		{
+			name: "removed",
+			removed: []unusedfunc.UnusedFunction{
+				{Name: "example.com/c.gone", Position: token.Position{Filename: "c/c.go", Line: 1, Column: 6}},
+			},
+			expected: "a/a.go:3:6 example.com/a.helper\n" +
+				"b/b.go:7:6 example.com/b.helper\n" +
+				"removed since base report:\n" +
+				"  c/c.go:1:6 example.com/c.gone\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := New("text", tt.opts)
+			require.NoError(t, err)
+
+			result := testResult()
+			result.Removed = tt.removed
+			var buf bytes.Buffer
+			require.NoError(t, formatter.Format(result, &buf))
+			require.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestJSONFormatter_RoundTrip(t *testing.T) {
+	formatter, err := New("json", Options{Version: "v1.2.3"})
+	require.NoError(t, err)
+
+	result := testResult()
+	var buf bytes.Buffer
+	require.NoError(t, formatter.Format(result, &buf))
+	require.Contains(t, buf.String(), `"version": "v1.2.3"`)
+	require.Contains(t, buf.String(), `"estimated_removable_lines": 4`)
+
+	functions, err := ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, result.UnusedFunctions, functions)
+}
+
+func TestJSONFormatter_FormatVersion(t *testing.T) {
+	for _, version := range []int{0, JSONFormatVersion} {
+		formatter, err := New("json", Options{FormatVersion: version})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, formatter.Format(testResult(), &buf))
+		require.Contains(t, buf.String(), `"format_version": 1`)
+	}
+
+	formatter, err := New("json", Options{FormatVersion: JSONFormatVersion + 1})
+	require.NoError(t, err)
+	require.ErrorContains(t, formatter.Format(testResult(), io.Discard), "unsupported JSON format version 2")
+
+	// Reports without a version predate it and are version 1.
+	functions, err := ReadJSON(strings.NewReader(`{"unused_functions": [{"name": "example.com/a.helper"}]}`))
+	require.NoError(t, err)
+	require.Len(t, functions, 1)
+
+	_, err = ReadJSON(strings.NewReader(`{"format_version": 2, "unused_functions": []}`))
+	require.ErrorContains(t, err, "newer than the supported version 1")
+}
+
+func TestJSONFormatter_RuleURL(t *testing.T) {
+	formatter, err := New("json", Options{HelpURLBase: "https://example.com/reasons"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.Format(testResult(), &buf))
+	require.Contains(t, buf.String(), `"rule_url": "https://example.com/reasons#unexported-and-unused"`)
+}
+
+func TestTypeRollups(t *testing.T) {
+	method := func(name string, line int, receiver string, methods int) unusedfunc.UnusedFunction {
+		return unusedfunc.UnusedFunction{
+			Name:            name,
+			Position:        token.Position{Filename: "a/a.go", Line: line, Column: 6},
+			Receiver:        receiver,
+			ReceiverMethods: methods,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		functions []unusedfunc.UnusedFunction
+		expected  []TypeRollup
+	}{
+		{
+			name:      "functions_only",
+			functions: testResult().UnusedFunctions,
+			expected:  []TypeRollup{},
+		},
+		{
+			name: "grouped_by_receiver",
+			functions: []unusedfunc.UnusedFunction{
+				method("example.com/a.T.a", 1, "example.com/a.T", 2),
+				method("example.com/a.S.a", 2, "example.com/a.S", 3),
+				method("example.com/a.T.b", 3, "example.com/a.T", 2),
+			},
+			expected: []TypeRollup{
+				{Type: "example.com/a.S", UnusedMethods: 1, TotalMethods: 3},
+				{Type: "example.com/a.T", UnusedMethods: 2, TotalMethods: 2},
+			},
+		},
+		{
+			name: "instantiations_count_once",
+			functions: []unusedfunc.UnusedFunction{
+				method("example.com/a.Box[int].get", 1, "example.com/a.Box", 2),
+				method("example.com/a.Box[string].get", 1, "example.com/a.Box", 2),
+			},
+			expected: []TypeRollup{
+				{Type: "example.com/a.Box", UnusedMethods: 1, TotalMethods: 2},
+			},
+		},
+		{
+			name: "suppressed_skipped",
+			functions: []unusedfunc.UnusedFunction{
+				method("example.com/a.T.a", 1, "example.com/a.T", 1),
+				{Name: "example.com/a.S.a", Receiver: "example.com/a.S", ReceiverMethods: 1, Suppressed: true},
+			},
+			expected: []TypeRollup{
+				{Type: "example.com/a.T", UnusedMethods: 1, TotalMethods: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, TypeRollups(tt.functions))
+		})
+	}
+}
+
+func TestTextFormatter_TypeRollups(t *testing.T) {
+	result := testResult()
+	result.UnusedFunctions = append(result.UnusedFunctions,
+		unusedfunc.UnusedFunction{
+			Name:            "example.com/a.T.m",
+			Position:        token.Position{Filename: "a/a.go", Line: 9, Column: 12},
+			Reason:          "unexported and unused",
+			Package:         "example.com/a",
+			Receiver:        "example.com/a.T",
+			ReceiverMethods: 1,
+		})
+
+	compact, err := New("text", Options{})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, compact.Format(result, &buf))
+	require.NotContains(t, buf.String(), "types with unused methods")
+
+	verbose, err := New("text", Options{Verbose: true})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, verbose.Format(result, &buf))
+	require.Contains(t, buf.String(), "\ntypes with unused methods:\n"+
+		"  example.com/a.T: 1/1 methods unused (consider removing the type)\n")
+
+	js, err := New("json", Options{})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, js.Format(result, &buf))
+	require.Contains(t, buf.String(), `"unused_methods": 1`)
+}
+
+func TestFormatters_LowConfidence(t *testing.T) {
+	result := testResult()
+	result.UnusedFunctions[0].Confidence = unusedfunc.HighConfidence
+	result.UnusedFunctions[1].Confidence = unusedfunc.LowConfidence
+
+	text, err := New("text", Options{Verbose: true})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, text.Format(result, &buf))
+	require.Contains(t, buf.String(), "  a/a.go:3:6 example.com/a.helper (unexported and unused)\n")
+	require.Contains(t, buf.String(), "  b/b.go:7:6 example.com/b.helper (unexported and unused; low confidence)\n")
+
+	js, err := New("json", Options{})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, js.Format(result, &buf))
+	require.Contains(t, buf.String(), `"severity": "warning"`)
+	require.Contains(t, buf.String(), `"severity": "note"`)
+
+	functions, err := ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, result.UnusedFunctions, functions)
+}
+
+func TestFormatters_SupersededBy(t *testing.T) {
+	result := testResult()
+	result.UnusedFunctions[0].SupersededBy = "example.com/a.helperV2"
+
+	text, err := New("text", Options{Verbose: true})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, text.Format(result, &buf))
+	require.Contains(t, buf.String(), "  a/a.go:3:6 example.com/a.helper (unexported and unused; likely superseded by example.com/a.helperV2)\n")
+
+	js, err := New("json", Options{})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, js.Format(result, &buf))
+	functions, err := ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, "example.com/a.helperV2", functions[0].SupersededBy)
+}
+
+func TestRuleURL(t *testing.T) {
+	tests := []struct {
+		reason   string
+		expected string
+	}{
+		{reason: "unexported and unused", expected: "base#unexported-and-unused"},
+		{reason: "exported and unused (strict mode)", expected: "base#exported-and-unused-strict-mode"},
+		{reason: "exported in test-support package and unused", expected: "base#exported-in-test-support-package-and-unused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			require.Equal(t, tt.expected, RuleURL("base", tt.reason))
+		})
+	}
+}
+
+func TestOnelineFormatter(t *testing.T) {
+	formatter, err := New("oneline", Options{})
+	require.NoError(t, err)
+
+	result := testResult()
+	result.Stats.SuppressedFunctions = 3
+	var buf bytes.Buffer
+	require.NoError(t, formatter.Format(result, &buf))
+	require.Equal(t, "unusedfunc: 2 unused functions across 2 packages (3 suppressed)\n", buf.String())
+
+	buf.Reset()
+	result.UnusedFunctions = result.UnusedFunctions[:1]
+	result.Stats.UnusedFunctions = 1
+	require.NoError(t, formatter.Format(result, &buf))
+	require.Equal(t, "unusedfunc: 1 unused function across 1 package (3 suppressed)\n", buf.String())
+}
+
+func TestVimFormatter(t *testing.T) {
+	formatter, err := New("vim", Options{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.Format(testResult(), &buf))
+	require.Equal(t, "a/a.go:3:6: example.com/a.helper (unexported and unused)\n"+
+		"b/b.go:7:6: example.com/b.helper (unexported and unused)\n", buf.String())
+}
+
+type countFormatter struct{}
+
+func (countFormatter) Format(result *Result, w io.Writer) error {
+	_, err := io.WriteString(w, "2\n")
+	return err
+}
+
+func TestRegister(t *testing.T) {
+	_, err := New("count", Options{})
+	require.ErrorContains(t, err, `unknown format "count"`)
+
+	Register("count", func(Options) Formatter { return countFormatter{} })
+	require.Contains(t, Names(), "count")
+
+	formatter, err := New("count", Options{})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, formatter.Format(testResult(), &buf))
+	require.Equal(t, "2\n", buf.String())
+}
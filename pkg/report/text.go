@@ -0,0 +1,163 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/715d/unusedfunc/pkg/unusedfunc"
+)
+
+// textFormatter writes one finding per line, grouped by package or owner.
+type textFormatter struct {
+	opts Options
+}
+
+func (f *textFormatter) Format(result *Result, w io.Writer) error {
+	var output strings.Builder
+
+	if f.opts.Verbose {
+		slog.Info("",
+			"total_functions", result.Stats.TotalFunctions,
+			"unused_functions", result.Stats.UnusedFunctions,
+			"suppressed_functions", result.Stats.SuppressedFunctions,
+			"estimated_removable_lines", result.Stats.EstimatedRemovableLines,
+			"analysis_duration", result.Stats.AnalysisDuration.String())
+	}
+
+	switch {
+	case len(result.UnusedFunctions) == 0:
+		slog.Info("no unused functions found")
+	case f.opts.GroupByOwner:
+		f.writeOwnerSections(&output, result.UnusedFunctions)
+	default:
+		f.writePackageSections(&output, result.UnusedFunctions)
+	}
+	if f.opts.Verbose {
+		writeTypeRollups(&output, TypeRollups(result.UnusedFunctions))
+	}
+	writeRemoved(&output, result.Removed)
+
+	_, err := io.WriteString(w, output.String())
+	return err
+}
+
+func (f *textFormatter) writePackageSections(output *strings.Builder, functions []unusedfunc.UnusedFunction) {
+	// Group functions by package for better organization.
+	packageFunctions := make(map[string][]unusedfunc.UnusedFunction)
+	for _, fn := range functions {
+		packageFunctions[fn.Package] = append(packageFunctions[fn.Package], fn)
+	}
+
+	for _, pkg := range slices.Sorted(maps.Keys(packageFunctions)) {
+		functions := packageFunctions[pkg]
+		if len(packageFunctions) > 1 && f.opts.Verbose {
+			heading := pkg
+			if f.opts.ShortPackage {
+				heading = functions[0].PackageName
+			}
+			output.WriteString(fmt.Sprintf("\n%s:\n", heading))
+		}
+
+		for _, fn := range functions {
+			// Format: filename:line:column functionName (reason)
+			if !f.opts.Verbose {
+				// Compact format for non-verbose mode.
+				output.WriteString(fmt.Sprintf("%s:%d:%d %s\n",
+					fn.Position.Filename, fn.Position.Line, fn.Position.Column, fn.Name))
+			} else {
+				output.WriteString(fmt.Sprintf("  %s:%d:%d %s (%s)\n",
+					fn.Position.Filename, fn.Position.Line, fn.Position.Column, fn.Name, detail(fn)))
+			}
+		}
+	}
+}
+
+// unownedSection is the heading for findings not matched by any CODEOWNERS rule.
+const unownedSection = "(unowned)"
+
+// writeOwnerSections writes one section per owner. A finding with several
+// owners is listed under each of them so every team sees its share.
+func (f *textFormatter) writeOwnerSections(output *strings.Builder, functions []unusedfunc.UnusedFunction) {
+	ownerFunctions := make(map[string][]unusedfunc.UnusedFunction)
+	for _, fn := range functions {
+		if len(fn.Owners) == 0 {
+			ownerFunctions[unownedSection] = append(ownerFunctions[unownedSection], fn)
+			continue
+		}
+		for _, owner := range fn.Owners {
+			ownerFunctions[owner] = append(ownerFunctions[owner], fn)
+		}
+	}
+
+	owners := slices.Sorted(maps.Keys(ownerFunctions))
+	// Keep unowned findings last so assigned work comes first.
+	if i := slices.Index(owners, unownedSection); i >= 0 {
+		owners = append(slices.Delete(owners, i, i+1), unownedSection)
+	}
+
+	for i, owner := range owners {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		output.WriteString(fmt.Sprintf("%s:\n", owner))
+		for _, fn := range ownerFunctions[owner] {
+			if !f.opts.Verbose {
+				output.WriteString(fmt.Sprintf("  %s:%d:%d %s\n",
+					fn.Position.Filename, fn.Position.Line, fn.Position.Column, fn.Name))
+			} else {
+				output.WriteString(fmt.Sprintf("  %s:%d:%d %s (%s)\n",
+					fn.Position.Filename, fn.Position.Line, fn.Position.Column, fn.Name, detail(fn)))
+			}
+		}
+	}
+}
+
+// detail returns the reason of a finding, with its confidence when low,
+// whether only strict mode reports it and the function likely superseding
+// it, if any.
+func detail(fn unusedfunc.UnusedFunction) string {
+	d := fn.Reason
+	if fn.Confidence == unusedfunc.LowConfidence {
+		d += "; low confidence"
+	}
+	if fn.StrictOnly {
+		d += "; strict only"
+	}
+	if fn.SupersededBy != "" {
+		d += "; likely superseded by " + fn.SupersededBy
+	}
+	return d
+}
+
+// writeTypeRollups lists the types with unused methods, suggesting to
+// remove those whose methods are all unused.
+func writeTypeRollups(output *strings.Builder, rollups []TypeRollup) {
+	if len(rollups) == 0 {
+		return
+	}
+	output.WriteString("\ntypes with unused methods:\n")
+	for _, t := range rollups {
+		output.WriteString(fmt.Sprintf("  %s: %d/%d methods unused", t.Type, t.UnusedMethods, t.TotalMethods))
+		if t.AllUnused() {
+			output.WriteString(" (consider removing the type)")
+		}
+		output.WriteString("\n")
+	}
+}
+
+// writeRemoved lists the findings of a previous report that are no longer
+// reported.
+func writeRemoved(output *strings.Builder, removed []unusedfunc.UnusedFunction) {
+	if len(removed) == 0 {
+		return
+	}
+	output.WriteString("removed since base report:\n")
+	for _, fn := range removed {
+		output.WriteString(fmt.Sprintf("  %s:%d:%d %s\n",
+			fn.Position.Filename, fn.Position.Line, fn.Position.Column, fn.Name))
+	}
+}
@@ -0,0 +1,21 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// vimFormatter writes one "file:line:col: message" line per finding, which
+// Vim's quickfix list parses with the default errorformat of the Go
+// compiler plugin.
+type vimFormatter struct{}
+
+func (vimFormatter) Format(result *Result, w io.Writer) error {
+	for _, fn := range result.UnusedFunctions {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s (%s)\n",
+			fn.Position.Filename, fn.Position.Line, fn.Position.Column, fn.Name, fn.Reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
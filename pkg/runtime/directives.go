@@ -17,7 +17,8 @@ const (
 	DirectiveNorace
 	DirectiveNocheckptr
 	DirectiveLinkname
-	DirectiveCGoExport // CGo export directive
+	DirectiveCGoExport  // CGo export directive
+	DirectiveWasmExport // WebAssembly export directive
 )
 
 // DirectiveInfo contains information about a runtime directive found on a function.
@@ -27,13 +28,17 @@ type DirectiveInfo struct {
 	Valid     bool
 }
 
-// runtimeDirectives maps directive strings to their types
+// runtimeDirectives maps directive strings to their types. Other "//go:"
+// directives, such as //go:build, //go:debug or //go:generate, configure the
+// toolchain rather than the function and are not reachability signals.
 var runtimeDirectives = map[string]DirectiveType{
 	"go:nosplit":    DirectiveNosplit,
 	"go:noinline":   DirectiveNoinline,
 	"go:norace":     DirectiveNorace,
 	"go:nocheckptr": DirectiveNocheckptr,
 	"go:linkname":   DirectiveLinkname,
+	// The host of a WebAssembly module calls the functions it exports.
+	"go:wasmexport": DirectiveWasmExport,
 }
 
 // runtimeHookFunctions contains function names that are known runtime hooks
@@ -112,3 +117,39 @@ func parseDirective(comment string) *DirectiveInfo {
 func IsRuntimeHookFunction(name string) bool {
 	return runtimeHookFunctions[name]
 }
+
+// cgoExportPragmas export a Go function under a linker symbol name, the way
+// cgo's //export does. They name the function as their first argument, so
+// unlike other directives they need not appear in its doc comment.
+var cgoExportPragmas = []string{
+	"go:cgo_export_dynamic",
+	"go:cgo_export_static",
+}
+
+// CGoExportedFunctions returns the names of the functions exported by
+// //go:cgo_export_dynamic and //go:cgo_export_static pragmas in file.
+func CGoExportedFunctions(file *ast.File) []string {
+	var names []string
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			text, ok := strings.CutPrefix(comment.Text, "//")
+			if !ok {
+				continue
+			}
+			for _, pragma := range cgoExportPragmas {
+				args, ok := strings.CutPrefix(text, pragma+" ")
+				if !ok {
+					continue
+				}
+				fields := strings.Fields(args)
+				if len(fields) == 0 {
+					continue
+				}
+				// The local name may be qualified, as in "main.callback".
+				local := fields[0]
+				names = append(names, local[strings.LastIndex(local, ".")+1:])
+			}
+		}
+	}
+	return names
+}
@@ -3,6 +3,7 @@ package ssa
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/ssa/ssautil"
+	"golang.org/x/tools/go/types/typeutil"
 
 	"github.com/715d/unusedfunc/internal/rta"
 
@@ -41,12 +43,138 @@ type Analyzer struct {
 	// nameCache is used for computing canonical names
 	nameCache *analysis.NameCache
 
-	// strict mode: when true, exported functions are NOT automatically entry points
-	strict bool
+	// opts configures entry point selection and the RTA run
+	opts Options
+
+	// implementations is the interface implementation index computed by RTA
+	implementations map[*types.Interface][]types.Type
+
+	// assumedImpls is Options.AssumeImpl resolved to types
+	assumedImpls map[*types.Interface]types.Type
+
+	// assumedInvoked is Options.AssumeInterfaceUsed resolved to types
+	assumedInvoked []*types.Interface
+
+	// assumedRemoved is Options.AssumeRemoved resolved to objects
+	assumedRemoved map[types.Object]bool
+
+	// excludedPkgs is Options.ExcludedPackages resolved to packages
+	excludedPkgs map[*types.Package]bool
+
+	// compileAsserts are the compile-time interface assertions of the
+	// analyzed packages unless Options.IgnoreCompileAsserts is set
+	compileAsserts []rta.Conversion
+
+	// removed and removedPkgs are the functions and packages RTA treats as
+	// deleted, set only while reachableWithout reruns it
+	removed     map[types.Object]bool
+	removedPkgs map[*types.Package]bool
+
+	// truncated is set when an RTA run stopped at Options.MaxRTAVisits
+	truncated bool
+}
+
+// Options configures the SSA analyzer.
+type Options struct {
+	// Strict mode: when true, exported functions are NOT automatically entry points.
+	Strict bool
+
+	// MaxRTAVisits bounds the number of functions RTA visits before it stops
+	// with partial results. Zero means no limit.
+	MaxRTAVisits int
+
+	// BenchmarkOnly flags exported functions that are reachable only from
+	// Benchmark functions: they are marked unused with UsedOnlyByBenchmarks.
+	BenchmarkOnly bool
+
+	// ExampleOnly flags exported functions that are reachable only from
+	// Example functions, documented but otherwise unused: they are marked
+	// unused with UsedOnlyByExamples.
+	ExampleOnly bool
+
+	// TestSupportPattern matches the import paths of test-support packages,
+	// such as testutil. Like internal packages, their exported functions are
+	// not entry points: their only consumers are tests, which are analyzed.
+	TestSupportPattern *regexp.Regexp
+
+	// UnimportedPackages holds the import paths of library packages that no
+	// analyzed package imports. Like internal packages, their exported
+	// functions are not entry points. See analysis.UnimportedPackages.
+	UnimportedPackages map[string]bool
+
+	// UnexportedOnly makes exported library methods entry points through
+	// their declarations rather than through the method sets of their
+	// receiver types, which is much cheaper for packages with many types.
+	// Methods promoted through embedding are then not entry points, so an
+	// exported method that is reachable only by promotion may be reported
+	// as unused: the results are only valid for unexported functions.
+	UnexportedOnly bool
+
+	// AssumeImpl maps interfaces to the single concrete type assumed to
+	// implement them at run time, both given as "import/path.Name". The
+	// methods other implementations provide for the interface are then only
+	// reachable through other uses. See rta.Options.AssumeImpl: this is an
+	// unsafe assumption, made to see what a configuration leaves dead.
+	AssumeImpl map[string]string
+
+	// AssumeInterfaceUsed names interfaces, as "import/path.Name", that are
+	// called from outside the analyzed code. The methods implementing them
+	// are reachable even if the analyzed code never calls them.
+	AssumeInterfaceUsed []string
+
+	// K8sAware keeps the DeepCopy* methods of the types declared in
+	// packages that import k8s.io/apimachinery. Kubernetes code generators
+	// write them for the API machinery, which calls them through
+	// runtime.Object and scheme registration that may not be in the build.
+	K8sAware bool
+
+	// UnreferencedTypes are exported types that no analyzed code refers to.
+	// Their methods are not entry points even in library packages: a type
+	// nobody uses cannot have its methods called. See
+	// analysis.UnreferencedTypes.
+	UnreferencedTypes map[*types.TypeName]bool
+
+	// AssumeRemoved names functions, as "import/path.Func", and methods, as
+	// "import/path.Type.Method", to analyze as if they were deleted. The
+	// functions used only through them are marked unused with
+	// UnusedAfterRemoval, to scope a deletion before making it.
+	AssumeRemoved []string
+
+	// ExcludedPackages names packages, as "import/path", whose users do not
+	// count: the functions of other packages that only they reach are marked
+	// unused with UsedOnlyByExcluded. The excluded packages' own functions
+	// keep their status.
+	ExcludedPackages []string
+
+	// IgnoreCompileAsserts stops counting compile-time interface assertions,
+	// such as "var _ I = (*T)(nil)", as conversions of T to I. By default
+	// they count, keeping the methods of T that I requires, although the SSA
+	// builder drops them. Ignored, the methods of a type that is only
+	// asserted are reported like any other: no value of T is ever stored in
+	// an I.
+	IgnoreCompileAsserts bool
+
+	// Dual also runs reachability from the entry points of strict mode,
+	// without the public API of library packages, and marks the functions
+	// only that API reaches with StrictOnly. It has no effect with Strict.
+	Dual bool
+
+	// PreciseUnnamedInterfaces makes a method call through an unnamed
+	// interface, such as a parameter of type interface{ NetConn() net.Conn },
+	// reach only the types converted to an interface with the same methods,
+	// rather than every runtime type implementing it. See
+	// rta.Options.PreciseUnnamedInterfaces for when this is unsound.
+	PreciseUnnamedInterfaces bool
 }
 
 // NewAnalyzer creates a new SSA analyzer for the given packages.
 func NewAnalyzer(pkgs []*packages.Package, strict bool) (*Analyzer, error) {
+	return NewAnalyzerWithOptions(pkgs, Options{Strict: strict})
+}
+
+// NewAnalyzerWithOptions creates a new SSA analyzer for the given packages,
+// configured by opts.
+func NewAnalyzerWithOptions(pkgs []*packages.Package, opts Options) (*Analyzer, error) {
 	// Filter out nil packages.
 	validPkgs := make([]*packages.Package, 0, len(pkgs))
 	for _, pkg := range pkgs {
@@ -63,18 +191,56 @@ func NewAnalyzer(pkgs []*packages.Package, strict bool) (*Analyzer, error) {
 	sa := &Analyzer{
 		packages:  validPkgs,
 		nameCache: analysis.NewNameCache(),
-		strict:    strict,
+		opts:      opts,
 	}
 
 	if err := sa.buildSSAProgram(); err != nil {
 		return nil, fmt.Errorf("build ssa program: %w", err)
 	}
 
+	if !opts.IgnoreCompileAsserts {
+		sa.compileAsserts = compileAsserts(validPkgs)
+	}
+
+	var err error
+	if sa.assumedImpls, err = sa.resolveAssumedImpls(); err != nil {
+		return nil, fmt.Errorf("assume implementation: %w", err)
+	}
+	for _, name := range opts.AssumeInterfaceUsed {
+		iface, err := sa.lookupInterface(name)
+		if err != nil {
+			return nil, fmt.Errorf("assume interface used: %w", err)
+		}
+		sa.assumedInvoked = append(sa.assumedInvoked, iface)
+	}
+	for _, name := range opts.AssumeRemoved {
+		obj, err := sa.lookupFunc(name)
+		if err != nil {
+			return nil, fmt.Errorf("assume removed: %w", err)
+		}
+		if sa.assumedRemoved == nil {
+			sa.assumedRemoved = make(map[types.Object]bool)
+		}
+		sa.assumedRemoved[obj] = true
+	}
+	for _, path := range opts.ExcludedPackages {
+		pkg := sa.program.ImportedPackage(path)
+		if pkg == nil {
+			return nil, fmt.Errorf("exclude package: package %q not found", path)
+		}
+		if sa.excludedPkgs == nil {
+			sa.excludedPkgs = make(map[*types.Package]bool)
+		}
+		sa.excludedPkgs[pkg.Pkg] = true
+	}
+
 	return sa, nil
 }
 
 // AnalyzeFuncs performs SSA-based analysis to mark reachable functions as used.
 func (sa *Analyzer) AnalyzeFuncs(funcs map[types.Object]*analysis.FuncInfo) error {
+	sa.truncated = false
+
 	// First, add functions with runtime directives as entry points.
 	sa.addRuntimeDirectiveFunctions(funcs)
 
@@ -87,9 +253,44 @@ func (sa *Analyzer) AnalyzeFuncs(funcs map[types.Object]*analysis.FuncInfo) erro
 		return err
 	}
 
-	// Create a map for matching by package path and name.
-	// This is needed because generic instantiations may create different types.Object.
-	// instances for the same logical method
+	// Mark reachable methods as used.
+	reachableByName := sa.reachableNames(reachable)
+	for obj, methodInfo := range funcs {
+		if sa.isReachable(obj, reachable, reachableByName) {
+			methodInfo.IsUsed = true
+		}
+	}
+
+	if sa.opts.Dual && !sa.opts.Strict {
+		if err := sa.markStrictOnly(funcs); err != nil {
+			return err
+		}
+	}
+	if sa.opts.BenchmarkOnly {
+		if err := sa.markBenchmarkOnly(funcs); err != nil {
+			return err
+		}
+	}
+	if sa.opts.ExampleOnly {
+		if err := sa.markExampleOnly(funcs); err != nil {
+			return err
+		}
+	}
+	if len(sa.excludedPkgs) > 0 {
+		if err := sa.markUsedOnlyByExcluded(funcs); err != nil {
+			return err
+		}
+	}
+	if len(sa.assumedRemoved) > 0 {
+		return sa.markUnusedAfterRemoval(funcs)
+	}
+	return nil
+}
+
+// reachableNames returns the canonical names of the reachable objects.
+// Matching by package path and name is needed because generic instantiations
+// may create different types.Object instances for the same logical method.
+func (sa *Analyzer) reachableNames(reachable Set[types.Object]) Set[string] {
 	reachableByName := make(Set[string], len(reachable))
 	for obj := range reachable {
 		// Skip objects without a package (built-in types, universe scope, etc.)
@@ -103,36 +304,184 @@ func (sa *Analyzer) AnalyzeFuncs(funcs map[types.Object]*analysis.FuncInfo) erro
 		key := sa.nameCache.ComputeObjectName(obj)
 		reachableByName[key] = struct{}{}
 	}
+	return reachableByName
+}
 
-	// Mark reachable methods as used.
-	for obj, methodInfo := range funcs {
+// isReachable reports whether obj is reachable, directly or by name.
+func (sa *Analyzer) isReachable(obj types.Object, reachable Set[types.Object], reachableByName Set[string]) bool {
+	if _, ok := reachable[obj]; ok {
+		return true
+	}
 
-		if _, ok := reachable[obj]; ok {
-			methodInfo.IsUsed = true
+	// Skip name-based matching for objects without a package (built-in types, universe scope, etc.)
+	// and anonymous functions (they have empty names).
+	if obj.Pkg() == nil || obj.Name() == "" {
+		return false
+	}
+
+	_, ok := reachableByName[sa.nameCache.ComputeObjectName(obj)]
+	return ok
+}
+
+// markBenchmarkOnly marks the exported functions reachable only from
+// Benchmark functions as unused, only used by benchmarks.
+func (sa *Analyzer) markBenchmarkOnly(funcs map[types.Object]*analysis.FuncInfo) error {
+	if err := sa.markReachableOnlyFrom(funcs, sa.isBenchmarkFunction, func(fi *analysis.FuncInfo) {
+		fi.UsedOnlyByBenchmarks = true
+	}); err != nil {
+		return fmt.Errorf("reachability without benchmarks: %w", err)
+	}
+	return nil
+}
+
+// markExampleOnly marks the exported functions reachable only from Example
+// functions as unused, only used by examples.
+func (sa *Analyzer) markExampleOnly(funcs map[types.Object]*analysis.FuncInfo) error {
+	if err := sa.markReachableOnlyFrom(funcs, sa.isExampleFunction, func(fi *analysis.FuncInfo) {
+		fi.UsedOnlyByExamples = true
+	}); err != nil {
+		return fmt.Errorf("reachability without examples: %w", err)
+	}
+	return nil
+}
+
+// markReachableOnlyFrom reruns reachability without the entry points
+// selected by only, and marks the used exported functions that are no
+// longer reached as unused, calling mark on them.
+func (sa *Analyzer) markReachableOnlyFrom(funcs map[types.Object]*analysis.FuncInfo, only func(*ssa.Function) bool, mark func(*analysis.FuncInfo)) error {
+	without := make([]*ssa.Function, 0, len(sa.entryPoints))
+	excluded := make(Set[types.Object])
+	for _, fn := range sa.entryPoints {
+		if only(fn) {
+			if fn.Object() != nil {
+				excluded[fn.Object()] = struct{}{}
+			}
 			continue
 		}
+		without = append(without, fn)
+	}
+	if len(excluded) == 0 {
+		return nil
+	}
 
-		// Skip name-based matching for objects without a package (built-in types, universe scope, etc.)
-		if obj.Pkg() == nil {
+	// The implementation graph reported to callers is the full program's.
+	entryPoints, implementations := sa.entryPoints, sa.implementations
+	sa.entryPoints = without
+	defer func() {
+		sa.entryPoints, sa.implementations = entryPoints, implementations
+	}()
+
+	reachable, err := sa.findReachableMethods()
+	if err != nil {
+		return err
+	}
+	reachableByName := sa.reachableNames(reachable)
+	for obj, fi := range funcs {
+		if !fi.IsUsed || !fi.IsExported {
 			continue
 		}
-
-		// Skip anonymous functions (they have empty names)
-		if obj.Name() == "" {
+		if _, ok := excluded[obj]; ok {
 			continue
 		}
+		if !sa.isReachable(obj, reachable, reachableByName) {
+			fi.IsUsed = false
+			mark(fi)
+		}
+	}
+	return nil
+}
 
-		// Try name-based match for objects with packages.
-		key := sa.nameCache.ComputeObjectName(obj)
-		if _, ok := reachableByName[key]; ok {
-			methodInfo.IsUsed = true
+// markStrictOnly reruns reachability from the entry points of strict mode,
+// and marks the used functions that are no longer reached as StrictOnly.
+// They stay used: only the public API of library packages reaches them.
+func (sa *Analyzer) markStrictOnly(funcs map[types.Object]*analysis.FuncInfo) error {
+	// The implementation graph reported to callers is the full program's.
+	entryPoints, templates, implementations := sa.entryPoints, sa.exportedTemplateObjects, sa.implementations
+	sa.opts.Strict, sa.exportedTemplateObjects = true, nil
+	defer func() {
+		sa.opts.Strict = false
+		sa.entryPoints, sa.exportedTemplateObjects, sa.implementations = entryPoints, templates, implementations
+	}()
+	sa.findEntryPoints()
+	sa.addRuntimeDirectiveFunctions(funcs)
+	sa.addAssemblyRelatedFunctions(funcs)
+
+	reachable, err := sa.findReachableMethods()
+	if err != nil {
+		return fmt.Errorf("strict reachability: %w", err)
+	}
+	reachableByName := sa.reachableNames(reachable)
+	for obj, fi := range funcs {
+		if fi.IsUsed && !sa.isReachable(obj, reachable, reachableByName) {
+			fi.StrictOnly = true
 		}
+	}
+	return nil
+}
 
+// markUnusedAfterRemoval reruns reachability as if the functions of
+// Options.AssumeRemoved were deleted, and marks the used functions that are
+// no longer reached as unused after their removal. The removed functions
+// themselves keep their status.
+func (sa *Analyzer) markUnusedAfterRemoval(funcs map[types.Object]*analysis.FuncInfo) error {
+	reachable, err := sa.reachableWithout(sa.assumedRemoved, nil)
+	if err != nil {
+		return fmt.Errorf("reachability after removal: %w", err)
 	}
+	reachableByName := sa.reachableNames(reachable)
+	for obj, fi := range funcs {
+		if !fi.IsUsed || sa.assumedRemoved[obj] {
+			continue
+		}
+		if !sa.isReachable(obj, reachable, reachableByName) {
+			fi.IsUsed = false
+			fi.UnusedAfterRemoval = true
+		}
+	}
+	return nil
+}
 
+// markUsedOnlyByExcluded reruns reachability as if the packages of
+// Options.ExcludedPackages were deleted, and marks the used functions of
+// the other packages that are no longer reached as used only by the
+// excluded packages.
+func (sa *Analyzer) markUsedOnlyByExcluded(funcs map[types.Object]*analysis.FuncInfo) error {
+	reachable, err := sa.reachableWithout(nil, sa.excludedPkgs)
+	if err != nil {
+		return fmt.Errorf("reachability without excluded packages: %w", err)
+	}
+	reachableByName := sa.reachableNames(reachable)
+	for obj, fi := range funcs {
+		if !fi.IsUsed || sa.excludedPkgs[obj.Pkg()] {
+			continue
+		}
+		if !sa.isReachable(obj, reachable, reachableByName) {
+			fi.IsUsed = false
+			fi.UsedOnlyByExcluded = true
+		}
+	}
 	return nil
 }
 
+// reachableWithout reruns reachability as if the given functions and the
+// functions, methods and initializers of the given packages were deleted.
+// The implementation graph reported to callers stays the full program's.
+func (sa *Analyzer) reachableWithout(removed map[types.Object]bool, removedPkgs map[*types.Package]bool) (Set[types.Object], error) {
+	without := make([]*ssa.Function, 0, len(sa.entryPoints))
+	for _, fn := range sa.entryPoints {
+		if !removed[fn.Object()] && (fn.Pkg == nil || !removedPkgs[fn.Pkg.Pkg]) {
+			without = append(without, fn)
+		}
+	}
+
+	entryPoints, implementations := sa.entryPoints, sa.implementations
+	sa.entryPoints, sa.removed, sa.removedPkgs = without, removed, removedPkgs
+	defer func() {
+		sa.entryPoints, sa.implementations, sa.removed, sa.removedPkgs = entryPoints, implementations, nil, nil
+	}()
+	return sa.findReachableMethods()
+}
+
 // buildSSAProgram constructs the SSA representation with generic instantiation
 func (sa *Analyzer) buildSSAProgram() error {
 	// Create SSA program with InstantiateGenerics mode for proper generic analysis.
@@ -214,10 +563,10 @@ func (sa *Analyzer) findEntryPoints() {
 				// In strict mode: don't add any exported functions as entry points (check if actually used).
 				// In normal mode: add non-internal exported functions as entry points (public API).
 				if sa.isExportedFunction(fn) && pkg.Pkg.Name() != mainPkg {
-					isInternal := sa.isInternalPackage(pkg.Pkg.Path())
+					isInternal := sa.isInternalPackage(pkg.Pkg.Path()) || sa.isTestSupportPackage(pkg.Pkg.Path()) || sa.opts.UnimportedPackages[pkg.Pkg.Path()]
 					// Strict mode: never add (check all for usage).
 					// Normal mode: add only non-internal (public API assumed used).
-					shouldAdd := !sa.strict && !isInternal
+					shouldAdd := !sa.opts.Strict && !isInternal
 
 					if shouldAdd {
 						// Only add if it's a function, not a method.
@@ -234,49 +583,17 @@ func (sa *Analyzer) findEntryPoints() {
 		// Add exported methods as entry points for library packages.
 		// This ensures that unexported methods called by exported methods are not marked as unused.
 		// In strict mode, skip this entirely (check all methods for actual usage).
-		if pkg.Pkg.Name() != mainPkg && !sa.strict && !sa.isInternalPackage(pkg.Pkg.Path()) {
-			for _, member := range pkg.Members {
-				if typ, ok := member.(*ssa.Type); ok && typ != nil {
-					// Get the underlying types.Type.
-					if namedType, ok := typ.Object().Type().(*types.Named); ok {
-						// Get all methods for this type (including pointer receivers)
-						mset := sa.program.MethodSets.MethodSet(namedType)
-						for i := range mset.Len() {
-							sel := mset.At(i)
-							if sel.Obj().Exported() {
-								// Get the SSA function for this method.
-								if fn := sa.program.MethodValue(sel); fn != nil {
-									sa.entryPoints = append(sa.entryPoints, fn)
-								} else if sel.Obj() != nil {
-									// Generic template method - no SSA function exists.
-									// Mark as entry point by adding to analysis directly.
-									sa.exportedTemplateObjects = append(sa.exportedTemplateObjects, sel.Obj())
-								}
-							}
-						}
-
-						// Also check pointer type methods.
-						ptrType := types.NewPointer(namedType)
-						ptrMset := sa.program.MethodSets.MethodSet(ptrType)
-						for i := range ptrMset.Len() {
-							sel := ptrMset.At(i)
-							if sel.Obj().Exported() {
-								if fn := sa.program.MethodValue(sel); fn != nil {
-									if !slices.Contains(sa.entryPoints, fn) {
-										sa.entryPoints = append(sa.entryPoints, fn)
-									}
-								} else if sel.Obj() != nil {
-									// Generic template method - no SSA function exists.
-									// Mark as entry point by adding to analysis directly.
-									if !slices.Contains(sa.exportedTemplateObjects, sel.Obj()) {
-										sa.exportedTemplateObjects = append(sa.exportedTemplateObjects, sel.Obj())
-									}
-								}
-							}
-						}
-					}
-				}
+		if pkg.Pkg.Name() != mainPkg && !sa.opts.Strict && !sa.isInternalPackage(pkg.Pkg.Path()) && !sa.isTestSupportPackage(pkg.Pkg.Path()) && !sa.opts.UnimportedPackages[pkg.Pkg.Path()] {
+			if sa.opts.UnexportedOnly {
+				sa.addDeclaredExportedMethods(pkg)
+			} else {
+				sa.addMethodSetEntryPoints(pkg)
 			}
+			sa.entryPoints = append(sa.entryPoints, exportedDataFuncs(pkg)...)
+		}
+
+		if sa.opts.K8sAware && importsAPIMachinery(pkg.Pkg) {
+			sa.addDeepCopyMethods(pkg)
 		}
 
 		// Add functions that might be called via reflection or build tags.
@@ -290,6 +607,116 @@ func (sa *Analyzer) findEntryPoints() {
 	}
 }
 
+// addMethodSetEntryPoints adds the exported methods in the method sets of
+// the types of pkg, including promoted ones, as entry points.
+func (sa *Analyzer) addMethodSetEntryPoints(pkg *ssa.Package) {
+	for _, member := range pkg.Members {
+		if typ, ok := member.(*ssa.Type); ok && typ != nil {
+			// Get the underlying types.Type.
+			if namedType, ok := typ.Object().Type().(*types.Named); ok && !sa.opts.UnreferencedTypes[namedType.Obj()] {
+				// Get all methods for this type (including pointer receivers)
+				mset := sa.program.MethodSets.MethodSet(namedType)
+				for i := range mset.Len() {
+					sel := mset.At(i)
+					if sel.Obj().Exported() {
+						// Get the SSA function for this method.
+						if fn := sa.program.MethodValue(sel); fn != nil {
+							sa.entryPoints = append(sa.entryPoints, fn)
+						} else if sel.Obj() != nil {
+							// Generic template method - no SSA function exists.
+							// Mark as entry point by adding to analysis directly.
+							sa.exportedTemplateObjects = append(sa.exportedTemplateObjects, sel.Obj())
+						}
+					}
+				}
+
+				// Also check pointer type methods.
+				ptrType := types.NewPointer(namedType)
+				ptrMset := sa.program.MethodSets.MethodSet(ptrType)
+				for i := range ptrMset.Len() {
+					sel := ptrMset.At(i)
+					if sel.Obj().Exported() {
+						if fn := sa.program.MethodValue(sel); fn != nil {
+							if !slices.Contains(sa.entryPoints, fn) {
+								sa.entryPoints = append(sa.entryPoints, fn)
+							}
+						} else if sel.Obj() != nil {
+							// Generic template method - no SSA function exists.
+							// Mark as entry point by adding to analysis directly.
+							if !slices.Contains(sa.exportedTemplateObjects, sel.Obj()) {
+								sa.exportedTemplateObjects = append(sa.exportedTemplateObjects, sel.Obj())
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// addDeclaredExportedMethods adds the exported methods declared in pkg as
+// entry points. See Options.UnexportedOnly.
+func (sa *Analyzer) addDeclaredExportedMethods(pkg *ssa.Package) {
+	for _, member := range pkg.Members {
+		typ, ok := member.(*ssa.Type)
+		if !ok {
+			continue
+		}
+		named, ok := typ.Object().Type().(*types.Named)
+		if !ok || sa.opts.UnreferencedTypes[named.Obj()] {
+			continue
+		}
+		for i := range named.NumMethods() {
+			method := named.Method(i)
+			if !method.Exported() {
+				continue
+			}
+			if named.TypeParams().Len() > 0 {
+				// Generic template method - no SSA function exists.
+				sa.exportedTemplateObjects = append(sa.exportedTemplateObjects, method)
+			} else if fn := sa.program.FuncValue(method); fn != nil {
+				sa.entryPoints = append(sa.entryPoints, fn)
+			}
+		}
+	}
+}
+
+// addDeepCopyMethods adds the DeepCopy, DeepCopyInto and DeepCopyObject
+// methods, and any other method named DeepCopy*, declared in pkg as entry
+// points. See Options.K8sAware.
+func (sa *Analyzer) addDeepCopyMethods(pkg *ssa.Package) {
+	for _, member := range pkg.Members {
+		typ, ok := member.(*ssa.Type)
+		if !ok {
+			continue
+		}
+		named, ok := typ.Object().Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue
+		}
+		for i := range named.NumMethods() {
+			method := named.Method(i)
+			if !strings.HasPrefix(method.Name(), "DeepCopy") {
+				continue
+			}
+			if fn := sa.program.FuncValue(method); fn != nil {
+				sa.entryPoints = append(sa.entryPoints, fn)
+			}
+		}
+	}
+}
+
+// importsAPIMachinery reports whether pkg directly imports a package of
+// k8s.io/apimachinery.
+func importsAPIMachinery(pkg *types.Package) bool {
+	for _, imp := range pkg.Imports() {
+		if path := imp.Path(); path == "k8s.io/apimachinery" || strings.HasPrefix(path, "k8s.io/apimachinery/") {
+			return true
+		}
+	}
+	return false
+}
+
 func (sa *Analyzer) isPotentialReflectionTarget(fn *ssa.Function) bool {
 	// Functions that might be called via reflection should be considered entry points.
 	// This is a conservative approach to avoid false positives.
@@ -308,6 +735,89 @@ func (sa *Analyzer) isPotentialReflectionTarget(fn *ssa.Function) bool {
 	return slices.Contains(reflectionPatterns, name)
 }
 
+// Implements returns the interface implementation graph computed during
+// AnalyzeFuncs: each interface that was the target of a type assertion or
+// interface conversion, mapped to the sorted names of its implementing types.
+// Declared interfaces are named "pkgpath.Name"; others use their type string.
+// Standard library interfaces and types are omitted.
+func (sa *Analyzer) Implements() map[string][]string {
+	if len(sa.implementations) == 0 {
+		return nil
+	}
+
+	// RTA keys interfaces by their underlying type; recover declared names.
+	var names typeutil.Map
+	for _, pkg := range sa.program.AllPackages() {
+		for _, member := range pkg.Members {
+			if t, ok := member.(*ssa.Type); ok {
+				if _, isIface := t.Type().Underlying().(*types.Interface); isIface {
+					names.Set(t.Type().Underlying(), t.Object())
+				}
+			}
+		}
+	}
+
+	graph := make(map[string][]string, len(sa.implementations))
+	for iface, impls := range sa.implementations {
+		name := iface.String()
+		if obj, ok := names.At(iface).(types.Object); ok {
+			if isStdlibPackage(obj.Pkg()) {
+				continue
+			}
+			name = obj.Pkg().Path() + "." + obj.Name()
+		}
+		for _, T := range impls {
+			if isStdlibPackage(typePackage(T)) {
+				continue
+			}
+			// RTA's candidates include T when only *T implements the
+			// interface, even if *T is never converted; report the precise
+			// relation.
+			if !types.Implements(T, iface) {
+				if _, isPtr := T.(*types.Pointer); isPtr || !types.Implements(types.NewPointer(T), iface) {
+					continue
+				}
+				T = types.NewPointer(T)
+			}
+			graph[name] = append(graph[name], T.String())
+		}
+		if len(graph[name]) == 0 {
+			delete(graph, name)
+			continue
+		}
+		slices.Sort(graph[name])
+		graph[name] = slices.Compact(graph[name])
+	}
+	return graph
+}
+
+// Truncated reports whether reachability analysis stopped at
+// Options.MaxRTAVisits during the last call to AnalyzeFuncs. Functions it had
+// not visited yet are then reported unused although they may be used.
+func (sa *Analyzer) Truncated() bool {
+	return sa.truncated
+}
+
+// typePackage returns the package declaring T, or *T's element, if named.
+func typePackage(T types.Type) *types.Package {
+	if ptr, ok := T.(*types.Pointer); ok {
+		T = ptr.Elem()
+	}
+	if named, ok := types.Unalias(T).(*types.Named); ok {
+		return named.Obj().Pkg()
+	}
+	return nil
+}
+
+// isStdlibPackage reports whether pkg belongs to the standard library.
+func isStdlibPackage(pkg *types.Package) bool {
+	if pkg == nil {
+		return false
+	}
+	_, ok := getStdLibSet()[pkg.Path()]
+	return ok
+}
+
 // findReachableMethods returns a set of all methods reachable from entry points
 // Uses Rapid Type Analysis (RTA) from the Go toolchain for proven correctness.
 func (sa *Analyzer) findReachableMethods() (Set[types.Object], error) {
@@ -341,10 +851,22 @@ func (sa *Analyzer) findReachableMethods() (Set[types.Object], error) {
 	}
 
 	// Analyze with our fork of RTA which has been modified to be more precise.
-	result := rta.Analyze(concreteEntryPoints)
+	result := rta.Analyze(concreteEntryPoints, rta.Options{
+		MaxVisits:                sa.opts.MaxRTAVisits,
+		AssumeImpl:               sa.assumedImpls,
+		AssumeInvoked:            sa.assumedInvoked,
+		Removed:                  sa.removed,
+		RemovedPkgs:              sa.removedPkgs,
+		Conversions:              sa.compileAsserts,
+		PreciseUnnamedInterfaces: sa.opts.PreciseUnnamedInterfaces,
+	})
 	if result == nil {
 		return nil, fmt.Errorf("RTA analysis failed")
 	}
+	sa.implementations = result.Implementations
+	if result.Truncated {
+		sa.truncated = true
+	}
 
 	// Extract reachable functions from the Reachable map directly.
 	// This avoids the overhead of building the call graph.
@@ -372,7 +894,7 @@ func (sa *Analyzer) findReachableMethods() (Set[types.Object], error) {
 
 	// Mark exported template objects as reachable (they are entry points)
 	for _, obj := range sa.exportedTemplateObjects {
-		if obj != nil {
+		if obj != nil && !sa.removed[obj] && !sa.removedPkgs[obj.Pkg()] {
 			reachable[obj] = struct{}{}
 			// Analyze the template method body to find calls and mark callees as reachable.
 			sa.markTemplateMethodCalls(obj, reachable)
@@ -506,8 +1028,9 @@ func (sa *Analyzer) markTemplateMethodCalls(methodObj types.Object, reachable Se
 // addRuntimeDirectiveFunctions adds functions with runtime directives as entry points
 func (sa *Analyzer) addRuntimeDirectiveFunctions(methods map[types.Object]*analysis.FuncInfo) {
 	for obj, funcInfo := range methods {
-		// If the function has runtime directives or CGo export, add it as an entry point.
-		if funcInfo.HasRuntimeDirective || funcInfo.HasCGoExport {
+		// If the function has runtime directives, or a CGo or WebAssembly
+		// export, add it as an entry point.
+		if funcInfo.HasRuntimeDirective || funcInfo.HasCGoExport || funcInfo.HasWasmExport {
 			// Find the corresponding SSA function using on-demand lookup.
 			if ssaFn := sa.getSSAFunction(obj); ssaFn != nil {
 				if !slices.Contains(sa.entryPoints, ssaFn) {
@@ -563,6 +1086,16 @@ func (sa *Analyzer) isTestFunction(fn *ssa.Function) bool {
 		strings.HasPrefix(name, "Example")
 }
 
+// isBenchmarkFunction checks if a function is a benchmark entry point.
+func (sa *Analyzer) isBenchmarkFunction(fn *ssa.Function) bool {
+	return strings.HasPrefix(fn.Name(), "Benchmark")
+}
+
+// isExampleFunction checks if a function is an example entry point.
+func (sa *Analyzer) isExampleFunction(fn *ssa.Function) bool {
+	return strings.HasPrefix(fn.Name(), "Example")
+}
+
 // isExportedFunction checks if a function is exported
 func (sa *Analyzer) isExportedFunction(fn *ssa.Function) bool {
 	return fn.Object() != nil && fn.Object().Exported()
@@ -576,4 +1109,98 @@ func (sa *Analyzer) isInternalPackage(pkgPath string) bool {
 		pkgPath == "internal"
 }
 
+// resolveAssumedImpls resolves the type names of Options.AssumeImpl.
+func (sa *Analyzer) resolveAssumedImpls() (map[*types.Interface]types.Type, error) {
+	if len(sa.opts.AssumeImpl) == 0 {
+		return nil, nil
+	}
+
+	impls := make(map[*types.Interface]types.Type, len(sa.opts.AssumeImpl))
+	for ifaceName, implName := range sa.opts.AssumeImpl {
+		iface, err := sa.lookupInterface(ifaceName)
+		if err != nil {
+			return nil, err
+		}
+		impl, err := sa.lookupType(implName)
+		if err != nil {
+			return nil, err
+		}
+		if types.IsInterface(impl) {
+			return nil, fmt.Errorf("%s is an interface, not a concrete type", implName)
+		}
+		if !types.Implements(impl, iface) && !types.Implements(types.NewPointer(impl), iface) {
+			return nil, fmt.Errorf("%s does not implement %s", implName, ifaceName)
+		}
+		impls[iface] = impl
+	}
+	return impls, nil
+}
+
+// lookupInterface returns the underlying interface of the package-level
+// interface type named by "import/path.Name".
+func (sa *Analyzer) lookupInterface(name string) (*types.Interface, error) {
+	t, err := sa.lookupType(name)
+	if err != nil {
+		return nil, err
+	}
+	iface, ok := t.Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", name)
+	}
+	return iface, nil
+}
+
+// lookupFunc returns the package-level function named by "import/path.Func",
+// or the method named by "import/path.Type.Method".
+func (sa *Analyzer) lookupFunc(name string) (types.Object, error) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid function name %q: want import/path.Func or import/path.Type.Method", name)
+	}
+	if pkg := sa.program.ImportedPackage(name[:i]); pkg != nil {
+		fn, ok := pkg.Pkg.Scope().Lookup(name[i+1:]).(*types.Func)
+		if !ok {
+			return nil, fmt.Errorf("function %s not found", name)
+		}
+		return fn, nil
+	}
+
+	t, err := sa.lookupType(name[:i])
+	if err != nil {
+		return nil, err
+	}
+	var pkg *types.Package
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		pkg = named.Obj().Pkg()
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, pkg, name[i+1:])
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("method %s not found", name)
+	}
+	return fn, nil
+}
+
+// lookupType returns the package-level type named by "import/path.Name".
+func (sa *Analyzer) lookupType(name string) (types.Type, error) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid type name %q: want import/path.Name", name)
+	}
+	pkg := sa.program.ImportedPackage(name[:i])
+	if pkg == nil {
+		return nil, fmt.Errorf("package %s is not loaded", name[:i])
+	}
+	obj, ok := pkg.Pkg.Scope().Lookup(name[i+1:]).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", name)
+	}
+	return obj.Type(), nil
+}
+
+// isTestSupportPackage checks if a package path matches TestSupportPattern.
+func (sa *Analyzer) isTestSupportPackage(pkgPath string) bool {
+	return sa.opts.TestSupportPattern != nil && sa.opts.TestSupportPattern.MatchString(pkgPath)
+}
+
 type Set[T comparable] map[T]struct{}
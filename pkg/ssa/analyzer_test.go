@@ -6,6 +6,7 @@ import (
 	"go/parser"
 	"go/token"
 	gotypes "go/types"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1189,3 +1190,776 @@ func main() {}
 		})
 	}
 }
+
+// TestSSAAnalyzer_MaxRTAVisits tests that the RTA visit limit stops the
+// analysis early with partial results.
+func TestSSAAnalyzer_MaxRTAVisits(t *testing.T) {
+	const code = `package main
+
+func a() { b() }
+func b() { c() }
+func c() {}
+
+func main() { a() }
+`
+
+	tests := []struct {
+		name         string
+		maxVisits    int
+		expectedUsed map[string]bool
+		truncated    bool
+	}{
+		{
+			name:         "unlimited",
+			maxVisits:    0,
+			expectedUsed: map[string]bool{"main": true, "a": true, "b": true, "c": true},
+		},
+		{
+			// Visiting main discovers a; the limit stops before a is visited.
+			name:         "stops after first visit",
+			maxVisits:    1,
+			expectedUsed: map[string]bool{"main": true, "a": true, "b": false, "c": false},
+			truncated:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         "test",
+				Name:       "main",
+				PkgPath:    "test",
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check("test", fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzerWithOptions([]*packages.Package{pkg}, Options{MaxRTAVisits: tt.maxVisits})
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+			require.Equal(t, tt.truncated, analyzer.Truncated())
+
+			for obj, fi := range funcs {
+				require.Equal(t, tt.expectedUsed[obj.Name()], fi.IsUsed, "function %s", obj.Name())
+			}
+		})
+	}
+}
+
+func TestSSAAnalyzer_Implements(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkgPath string
+		code    string
+		iface   string
+		want    []string
+	}{
+		{
+			name:    "converted_types",
+			pkgPath: "test",
+			code: `package main
+
+type Shape interface{ Area() int }
+
+type Square struct{}
+
+func (Square) Area() int { return 1 }
+
+type Circle struct{}
+
+func (*Circle) Area() int { return 3 }
+
+type Line struct{}
+
+func main() {
+	var v any = Square{}
+	_ = any(&Circle{})
+	_ = any(Line{})
+	if s, ok := v.(Shape); ok {
+		_ = s.Area()
+	}
+}
+`,
+			iface: "test.Shape",
+			want:  []string{"*test.Circle", "*test.Square", "test.Square"},
+		},
+		{
+			// Module packages are scanned for candidate implementations, so
+			// Triangle is one although no *Triangle is ever converted.
+			name:    "pointer_receiver_never_converted",
+			pkgPath: "example.com/shapes",
+			code: `package main
+
+type Shape interface{ Area() int }
+
+type Square struct{}
+
+func (Square) Area() int { return 1 }
+
+type Triangle struct{}
+
+func (*Triangle) Area() int { return 2 }
+
+func main() {
+	var v any = Square{}
+	if s, ok := v.(Shape); ok {
+		_ = s.Area()
+	}
+}
+`,
+			iface: "example.com/shapes.Shape",
+			want:  []string{"*example.com/shapes.Square", "*example.com/shapes.Triangle", "example.com/shapes.Square"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tt.code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         tt.pkgPath,
+				Name:       "main",
+				PkgPath:    tt.pkgPath,
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check(tt.pkgPath, fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzer([]*packages.Package{pkg}, false)
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+			require.Equal(t, tt.want, analyzer.Implements()[tt.iface])
+		})
+	}
+}
+
+func TestSSAAnalyzer_BenchmarkAndExampleOnly(t *testing.T) {
+	const code = `package bench
+
+type B struct{ N int }
+
+type T struct{ M int }
+
+// Setup is only used by benchmarks.
+func Setup() int { return 1 }
+
+// Shared is used by a benchmark and a test.
+func Shared() int { return 2 }
+
+// helper is unexported; only exported functions are flagged.
+func helper() int { return 3 }
+
+// Documented is only used by its example.
+func Documented() int { return 4 }
+
+func BenchmarkSetup(b *B) {
+	for range b.N {
+		_ = Setup() + Shared() + helper()
+	}
+}
+
+func TestShared(t *T) {
+	_ = Shared()
+}
+
+func ExampleDocumented() {
+	_ = Documented() + Shared()
+}
+`
+
+	tests := []struct {
+		name          string
+		benchmarkOnly bool
+		exampleOnly   bool
+		expectedUsed  map[string]bool
+	}{
+		{
+			name: "disabled",
+			expectedUsed: map[string]bool{
+				"Setup": true, "Shared": true, "helper": true, "Documented": true,
+				"BenchmarkSetup": true, "TestShared": true, "ExampleDocumented": true,
+			},
+		},
+		{
+			name:          "benchmark_only",
+			benchmarkOnly: true,
+			expectedUsed: map[string]bool{
+				"Setup": false, "Shared": true, "helper": true, "Documented": true,
+				"BenchmarkSetup": true, "TestShared": true, "ExampleDocumented": true,
+			},
+		},
+		{
+			name:        "example_only",
+			exampleOnly: true,
+			expectedUsed: map[string]bool{
+				"Setup": true, "Shared": true, "helper": true, "Documented": false,
+				"BenchmarkSetup": true, "TestShared": true, "ExampleDocumented": true,
+			},
+		},
+		{
+			name:          "both",
+			benchmarkOnly: true,
+			exampleOnly:   true,
+			expectedUsed: map[string]bool{
+				"Setup": false, "Shared": true, "helper": true, "Documented": false,
+				"BenchmarkSetup": true, "TestShared": true, "ExampleDocumented": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "bench_test.go", code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         "example.com/internal/bench",
+				Name:       "bench",
+				PkgPath:    "example.com/internal/bench",
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check(pkg.PkgPath, fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzerWithOptions([]*packages.Package{pkg}, Options{BenchmarkOnly: tt.benchmarkOnly, ExampleOnly: tt.exampleOnly})
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+			for obj, fi := range funcs {
+				require.Equal(t, tt.expectedUsed[obj.Name()], fi.IsUsed, "function %s", obj.Name())
+				require.Equal(t, !fi.IsUsed && obj.Name() == "Setup", fi.UsedOnlyByBenchmarks, "function %s", obj.Name())
+				require.Equal(t, !fi.IsUsed && obj.Name() == "Documented", fi.UsedOnlyByExamples, "function %s", obj.Name())
+			}
+		})
+	}
+}
+
+func TestSSAAnalyzer_UnexportedOnly(t *testing.T) {
+	const code = `package lib
+
+type Client struct{ *conn }
+
+type conn struct{}
+
+// Send is promoted to Client.
+func (c *conn) Send() { c.write() }
+
+func (c *conn) write() {}
+
+func (c *conn) close() {}
+
+type Value int
+
+func (v Value) String() string { return format(int(v)) }
+
+func format(int) string { return "" }
+
+func unused() {}
+`
+
+	tests := []struct {
+		name           string
+		unexportedOnly bool
+	}{
+		{name: "method_sets", unexportedOnly: false},
+		{name: "declared_methods", unexportedOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "lib.go", code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         "example.com/lib",
+				Name:       "lib",
+				PkgPath:    "example.com/lib",
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check(pkg.PkgPath, fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzerWithOptions([]*packages.Package{pkg}, Options{UnexportedOnly: tt.unexportedOnly})
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok && !fn.Exported() {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+			// The unexported results do not depend on how exported methods
+			// become entry points.
+			expectedUsed := map[string]bool{"write": true, "close": false, "format": true, "unused": false}
+			for obj, fi := range funcs {
+				require.Equal(t, expectedUsed[obj.Name()], fi.IsUsed, "function %s", obj.Name())
+			}
+		})
+	}
+}
+
+func TestSSAAnalyzer_AssumeImpl(t *testing.T) {
+	const code = `package main
+
+type Store interface{ Get() int }
+
+type memStore struct{}
+
+func (memStore) Get() int { return 1 }
+
+type diskStore struct{}
+
+func (*diskStore) Get() int { return 2 }
+
+func newStore(disk bool) Store {
+	if disk {
+		return &diskStore{}
+	}
+	return memStore{}
+}
+
+var disk bool
+
+func main() { println(newStore(disk).Get()) }
+`
+
+	tests := []struct {
+		name         string
+		assumeImpl   map[string]string
+		expectedUsed map[string]bool
+		expectedErr  string
+	}{
+		{
+			name:         "no_assumption",
+			expectedUsed: map[string]bool{"memStore.Get": true, "diskStore.Get": true},
+		},
+		{
+			name:         "assume_mem",
+			assumeImpl:   map[string]string{"example.com/app.Store": "example.com/app.memStore"},
+			expectedUsed: map[string]bool{"memStore.Get": true, "diskStore.Get": false},
+		},
+		{
+			name:         "assume_pointer_receiver",
+			assumeImpl:   map[string]string{"example.com/app.Store": "example.com/app.diskStore"},
+			expectedUsed: map[string]bool{"memStore.Get": false, "diskStore.Get": true},
+		},
+		{
+			name:        "not_an_interface",
+			assumeImpl:  map[string]string{"example.com/app.memStore": "example.com/app.diskStore"},
+			expectedErr: "example.com/app.memStore is not an interface",
+		},
+		{
+			name:        "unknown_type",
+			assumeImpl:  map[string]string{"example.com/app.Store": "example.com/app.netStore"},
+			expectedErr: "type example.com/app.netStore not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "main.go", code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         "example.com/app",
+				Name:       "main",
+				PkgPath:    "example.com/app",
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check(pkg.PkgPath, fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzerWithOptions([]*packages.Package{pkg}, Options{AssumeImpl: tt.assumeImpl})
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok && fn.Name() == "Get" && fn.Signature().Recv() != nil {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+			for obj, fi := range funcs {
+				recv := gotypes.TypeString(obj.(*gotypes.Func).Signature().Recv().Type(), func(*gotypes.Package) string { return "" })
+				name := strings.TrimPrefix(recv, "*") + ".Get"
+				require.Equal(t, tt.expectedUsed[name], fi.IsUsed, "function %s", name)
+			}
+		})
+	}
+}
+
+func TestSSAAnalyzer_AssumeInterfaceUsed(t *testing.T) {
+	const code = `package lib
+
+// Visitor is implemented here but only called by consumers.
+type Visitor interface{ Visit(n int) }
+
+type printer struct{}
+
+func (printer) Visit(n int) {}
+
+type counter struct{}
+
+func (*counter) Visit(n int) {}
+
+func (*counter) Reset() {}
+
+func Walk(n int) int { return n }
+`
+
+	tests := []struct {
+		name         string
+		assumeUsed   []string
+		expectedUsed map[string]bool
+		expectedErr  string
+	}{
+		{
+			name:         "no_assumption",
+			expectedUsed: map[string]bool{"printer.Visit": false, "counter.Visit": false, "counter.Reset": false},
+		},
+		{
+			name:         "assume_visitor_used",
+			assumeUsed:   []string{"example.com/internal/lib.Visitor"},
+			expectedUsed: map[string]bool{"printer.Visit": true, "counter.Visit": true, "counter.Reset": false},
+		},
+		{
+			name:        "not_an_interface",
+			assumeUsed:  []string{"example.com/internal/lib.printer"},
+			expectedErr: "example.com/internal/lib.printer is not an interface",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "lib.go", code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         "example.com/internal/lib",
+				Name:       "lib",
+				PkgPath:    "example.com/internal/lib",
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check(pkg.PkgPath, fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzerWithOptions([]*packages.Package{pkg}, Options{AssumeInterfaceUsed: tt.assumeUsed})
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok && fn.Signature().Recv() != nil {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+			for obj, fi := range funcs {
+				recv := gotypes.TypeString(obj.(*gotypes.Func).Signature().Recv().Type(), func(*gotypes.Package) string { return "" })
+				name := strings.TrimPrefix(recv, "*") + "." + obj.Name()
+				require.Equal(t, tt.expectedUsed[name], fi.IsUsed, "function %s", name)
+			}
+		})
+	}
+}
+
+func TestSSAAnalyzer_AssumeRemoved(t *testing.T) {
+	const code = `package main
+
+type client struct{}
+
+func (c *client) sync() { c.push() }
+
+func (c *client) push() { encode() }
+
+func encode() {}
+
+func importLegacy() { parse(); encode() }
+
+func parse() {}
+
+func main() {
+	importLegacy()
+	(&client{}).sync()
+}
+`
+
+	tests := []struct {
+		name          string
+		assumeRemoved []string
+		expectedUsed  map[string]bool
+		expectedErr   string
+	}{
+		{
+			name: "no_removal",
+			expectedUsed: map[string]bool{
+				"main": true, "importLegacy": true, "parse": true, "encode": true, "sync": true, "push": true,
+			},
+		},
+		{
+			name:          "remove_function",
+			assumeRemoved: []string{"example.com/app.importLegacy"},
+			expectedUsed: map[string]bool{
+				"main": true, "importLegacy": true, "parse": false, "encode": true, "sync": true, "push": true,
+			},
+		},
+		{
+			name:          "remove_function_and_method",
+			assumeRemoved: []string{"example.com/app.importLegacy", "example.com/app.client.sync"},
+			expectedUsed: map[string]bool{
+				"main": true, "importLegacy": true, "parse": false, "encode": false, "sync": true, "push": false,
+			},
+		},
+		{
+			name:          "unknown_function",
+			assumeRemoved: []string{"example.com/app.missing"},
+			expectedErr:   "function example.com/app.missing not found",
+		},
+		{
+			name:          "unknown_method",
+			assumeRemoved: []string{"example.com/app.client.missing"},
+			expectedErr:   "method example.com/app.client.missing not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "main.go", code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         "example.com/app",
+				Name:       "main",
+				PkgPath:    "example.com/app",
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check(pkg.PkgPath, fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzerWithOptions([]*packages.Package{pkg}, Options{AssumeRemoved: tt.assumeRemoved})
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+			for obj, fi := range funcs {
+				require.Equal(t, tt.expectedUsed[obj.Name()], fi.IsUsed, "function %s", obj.Name())
+				require.Equal(t, !fi.IsUsed, fi.UnusedAfterRemoval, "function %s", obj.Name())
+			}
+		})
+	}
+}
+
+func TestSSAAnalyzer_Dual(t *testing.T) {
+	const code = `package lib
+
+func API() int { return helper() }
+
+func helper() int { return 1 }
+
+func dead() {}
+
+type T struct{}
+
+func (T) Method() {}
+
+func init() { setup() }
+
+func setup() {}
+`
+
+	tests := []struct {
+		name               string
+		dual               bool
+		expectedStrictOnly map[string]bool
+	}{
+		{
+			name:               "normal",
+			expectedStrictOnly: map[string]bool{},
+		},
+		{
+			name: "dual",
+			dual: true,
+			expectedStrictOnly: map[string]bool{
+				"API": true, "helper": true, "Method": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "lib.go", code, parser.ParseComments)
+			require.NoError(t, err)
+
+			pkg := &packages.Package{
+				ID:         "example.com/lib",
+				Name:       "lib",
+				PkgPath:    "example.com/lib",
+				Syntax:     []*ast.File{file},
+				Fset:       fset,
+				TypesSizes: gotypes.SizesFor("gc", "amd64"),
+			}
+			info := &gotypes.Info{
+				Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+				Defs:       make(map[*ast.Ident]gotypes.Object),
+				Uses:       make(map[*ast.Ident]gotypes.Object),
+				Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+				Implicits:  make(map[ast.Node]gotypes.Object),
+			}
+			pkg.TypesInfo = info
+			conf := gotypes.Config{Importer: importer.Default()}
+			pkg.Types, err = conf.Check(pkg.PkgPath, fset, []*ast.File{file}, info)
+			require.NoError(t, err)
+
+			analyzer, err := NewAnalyzerWithOptions([]*packages.Package{pkg}, Options{Dual: tt.dual})
+			require.NoError(t, err)
+
+			funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+			for _, obj := range info.Defs {
+				if fn, ok := obj.(*gotypes.Func); ok && fn.Name() != "init" {
+					funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+				}
+			}
+			require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+			for obj, fi := range funcs {
+				require.Equal(t, obj.Name() != "dead", fi.IsUsed, "function %s", obj.Name())
+				require.Equal(t, tt.expectedStrictOnly[obj.Name()], fi.StrictOnly, "function %s", obj.Name())
+				if tt.dual {
+					require.Equal(t, obj.Name() != "setup", fi.ShouldReportStrict(), "function %s", obj.Name())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package ssa
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/715d/unusedfunc/internal/rta"
+)
+
+// compileAsserts returns the compile-time interface assertions of the target
+// packages among pkgs: blank package-level variables such as
+//
+//	var _ io.Writer = (*T)(nil)
+//	var _ = io.Writer(&T{})
+//
+// The SSA builder drops them, as they have no effect at run time, so RTA
+// would not otherwise see T converted to the interface.
+func compileAsserts(pkgs []*packages.Package) []rta.Conversion {
+	var convs []rta.Conversion
+	for _, pkg := range pkgs {
+		if !isTargetPackage(pkg) || pkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					vs := spec.(*ast.ValueSpec)
+					for i, name := range vs.Names {
+						if name.Name != "_" || i >= len(vs.Values) {
+							continue
+						}
+						if conv, ok := assertedConversion(pkg.TypesInfo, vs.Type, vs.Values[i]); ok {
+							convs = append(convs, conv)
+						}
+					}
+				}
+			}
+		}
+	}
+	return convs
+}
+
+// assertedConversion returns the conversion to a non-empty interface that
+// assigning value to a variable of type typ, or of the type of value if typ
+// is nil, asserts.
+func assertedConversion(info *types.Info, typ ast.Expr, value ast.Expr) (rta.Conversion, bool) {
+	var target types.Type
+	if typ != nil {
+		target = info.TypeOf(typ)
+	} else if call, ok := ast.Unparen(value).(*ast.CallExpr); ok && len(call.Args) == 1 && info.Types[call.Fun].IsType() {
+		target, value = info.TypeOf(call.Fun), call.Args[0]
+	}
+	if target == nil {
+		return rta.Conversion{}, false
+	}
+	iface, ok := target.Underlying().(*types.Interface)
+	if !ok || iface.NumMethods() == 0 {
+		return rta.Conversion{}, false
+	}
+
+	tv, ok := info.Types[value]
+	if !ok || tv.IsNil() || tv.Type == nil || types.IsInterface(tv.Type) {
+		return rta.Conversion{}, false
+	}
+	return rta.Conversion{T: tv.Type, Interface: iface}, true
+}
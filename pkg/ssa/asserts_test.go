@@ -0,0 +1,67 @@
+package ssa
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCompileAsserts(t *testing.T) {
+	const code = `package lib
+
+type I interface{ M() }
+
+type T struct{}
+
+func (*T) M() {}
+
+type U struct{}
+
+func (U) M() {}
+
+type V struct{}
+
+func (V) M() {}
+
+var _ I = (*T)(nil)
+
+var _ = I(U{})
+
+var _, _ I = V{}, nil
+
+var _ any = T{}
+
+var _ I = I(nil)
+
+var named I = &T{}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "lib.go", code, 0)
+	require.NoError(t, err)
+
+	info := &gotypes.Info{Types: make(map[ast.Expr]gotypes.TypeAndValue)}
+	conf := gotypes.Config{Importer: importer.Default()}
+	tpkg, err := conf.Check("example.com/lib", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	pkg := &packages.Package{
+		PkgPath:   "example.com/lib",
+		Syntax:    []*ast.File{file},
+		Types:     tpkg,
+		TypesInfo: info,
+	}
+
+	var got []string
+	for _, conv := range compileAsserts([]*packages.Package{pkg}) {
+		got = append(got, gotypes.TypeString(conv.T, gotypes.RelativeTo(tpkg)))
+		require.Equal(t, 1, conv.Interface.NumMethods())
+	}
+	require.Equal(t, []string{"*T", "U", "V"}, got)
+}
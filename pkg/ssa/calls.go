@@ -0,0 +1,59 @@
+package ssa
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// StaticCalls returns, for each function or method in among, the other
+// functions of among that its body, closures included, calls statically or
+// refers to as a value. Dynamic calls through interfaces and function values
+// are not followed. It works on declarations rather than on the reachable
+// program, so it finds the calls between unreachable functions too.
+func (sa *Analyzer) StaticCalls(among map[types.Object]bool) map[types.Object][]types.Object {
+	calls := make(map[types.Object][]types.Object)
+	for obj := range among {
+		fn, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+		ssaFn := sa.program.FuncValue(fn)
+		if ssaFn == nil {
+			continue
+		}
+
+		seen := make(map[types.Object]bool)
+		visitFuncRefs(ssaFn, func(callee *ssa.Function) {
+			target := callee.Object()
+			if target == nil || target == obj || seen[target] || !among[target] {
+				return
+			}
+			seen[target] = true
+			calls[obj] = append(calls[obj], target)
+		})
+	}
+	return calls
+}
+
+// visitFuncRefs calls visit for every function that an operand of an
+// instruction of fn, or of one of its anonymous functions, refers to.
+func visitFuncRefs(fn *ssa.Function, visit func(*ssa.Function)) {
+	var operands []*ssa.Value
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			operands = instr.Operands(operands[:0])
+			for _, op := range operands {
+				if op == nil {
+					continue
+				}
+				if callee, ok := (*op).(*ssa.Function); ok {
+					visit(callee)
+				}
+			}
+		}
+	}
+	for _, anon := range fn.AnonFuncs {
+		visitFuncRefs(anon, visit)
+	}
+}
@@ -0,0 +1,83 @@
+package ssa
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestSSAAnalyzer_StaticCalls(t *testing.T) {
+	const code = `package main
+
+type client struct{}
+
+func (c *client) sync() {
+	c.push()
+	go func() { encode() }()
+	run(live)
+}
+
+func (c *client) push() { _ = c.push }
+
+func encode() { encode() }
+
+func lone() {}
+
+func run(f func()) { f() }
+
+func live() {}
+
+func main() { run(live) }
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	require.NoError(t, err)
+
+	pkg := &packages.Package{
+		ID:         "test",
+		Name:       "main",
+		PkgPath:    "test",
+		Syntax:     []*ast.File{file},
+		Fset:       fset,
+		TypesSizes: gotypes.SizesFor("gc", "amd64"),
+	}
+	info := &gotypes.Info{
+		Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+		Defs:       make(map[*ast.Ident]gotypes.Object),
+		Uses:       make(map[*ast.Ident]gotypes.Object),
+		Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+		Implicits:  make(map[ast.Node]gotypes.Object),
+	}
+	pkg.TypesInfo = info
+	conf := gotypes.Config{Importer: importer.Default()}
+	pkg.Types, err = conf.Check("test", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	analyzer, err := NewAnalyzer([]*packages.Package{pkg}, false)
+	require.NoError(t, err)
+
+	// The calls to run and live are left out: they are not in the set.
+	among := make(map[gotypes.Object]bool)
+	for _, obj := range info.Defs {
+		if fn, ok := obj.(*gotypes.Func); ok && slices.Contains([]string{"sync", "push", "encode", "lone"}, fn.Name()) {
+			among[fn] = true
+		}
+	}
+
+	got := make(map[string][]string)
+	for caller, callees := range analyzer.StaticCalls(among) {
+		for _, callee := range callees {
+			got[caller.Name()] = append(got[caller.Name()], callee.Name())
+		}
+		slices.Sort(got[caller.Name()])
+	}
+	require.Equal(t, map[string][]string{"sync": {"encode", "push"}}, got)
+}
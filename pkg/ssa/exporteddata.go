@@ -0,0 +1,97 @@
+package ssa
+
+import "golang.org/x/tools/go/ssa"
+
+// exportedDataFuncs returns the functions that the initializers of the
+// package-level variables of pkg store into exported variables: directly,
+// into their fields or elements, or into the structs, arrays, slices and maps
+// they are set to. As in
+//
+//	var Registry = Handlers{OnEvent: handleEvent}
+//
+// importers may read the variable and call the functions, so in a library
+// they are as much a part of the API as exported functions.
+func exportedDataFuncs(pkg *ssa.Package) []*ssa.Function {
+	init := pkg.Func("init")
+	if init == nil {
+		return nil
+	}
+
+	var funcs []*ssa.Function
+	seen := make(map[ssa.Value]bool)
+	var collect, collectStored func(v ssa.Value)
+	collect = func(v ssa.Value) {
+		if seen[v] {
+			return
+		}
+		seen[v] = true
+		switch v := v.(type) {
+		case *ssa.Function:
+			funcs = append(funcs, v)
+		case *ssa.MakeClosure:
+			collect(v.Fn)
+		case *ssa.MakeInterface:
+			collect(v.X)
+		case *ssa.ChangeType:
+			collect(v.X)
+		case *ssa.Slice:
+			collectStored(v.X)
+		case *ssa.Alloc, *ssa.MakeMap, *ssa.MakeSlice:
+			collectStored(v)
+		}
+	}
+	// collectStored collects the values stored through addr, a pointer or
+	// a map, and through the addresses of its fields and elements.
+	collectStored = func(addr ssa.Value) {
+		refs := addr.Referrers()
+		if refs == nil {
+			return
+		}
+		for _, ref := range *refs {
+			switch ref := ref.(type) {
+			case *ssa.Store:
+				if ref.Addr == addr {
+					collect(ref.Val)
+				}
+			case *ssa.MapUpdate:
+				if ref.Map == addr {
+					collect(ref.Value)
+				}
+			case *ssa.FieldAddr:
+				collectStored(ref)
+			case *ssa.IndexAddr:
+				collectStored(ref)
+			}
+		}
+	}
+
+	for _, b := range init.Blocks {
+		for _, instr := range b.Instrs {
+			store, ok := instr.(*ssa.Store)
+			if !ok {
+				continue
+			}
+			if g := rootGlobal(store.Addr); g != nil && g.Object() != nil && g.Object().Exported() {
+				collect(store.Val)
+			}
+		}
+	}
+	return funcs
+}
+
+// rootGlobal returns the package-level variable addr is the address of, or
+// the address of a field or element of; nil if there is none.
+func rootGlobal(addr ssa.Value) *ssa.Global {
+	for {
+		switch a := addr.(type) {
+		case *ssa.Global:
+			return a
+		case *ssa.FieldAddr:
+			addr = a.X
+		case *ssa.IndexAddr:
+			addr = a.X
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package ssa
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+
+	"github.com/715d/unusedfunc/internal/analysis"
+)
+
+// CHADisagreements cross-checks the results of AnalyzeFuncs against Class
+// Hierarchy Analysis. It returns the functions RTA found unused that CHA
+// reaches from the same entry points. CHA over-approximates dynamic calls, so
+// a disagreement is not necessarily a bug, but every false positive of RTA
+// that is caused by a missed call edge shows up here.
+//
+// This is a debugging aid: CHA builds the call graph of the whole program,
+// including dependencies, and is much slower than the analysis itself.
+func (sa *Analyzer) CHADisagreements(funcs map[types.Object]*analysis.FuncInfo) []*analysis.FuncInfo {
+	cg := cha.CallGraph(sa.program)
+
+	reachable := make(Set[types.Object])
+	seen := make(Set[*ssa.Function])
+	worklist := append([]*ssa.Function(nil), sa.entryPoints...)
+	for len(worklist) > 0 {
+		fn := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		if _, ok := seen[fn]; ok {
+			continue
+		}
+		seen[fn] = struct{}{}
+
+		if obj := fn.Object(); obj != nil {
+			reachable[obj] = struct{}{}
+		}
+		if origin := fn.Origin(); origin != nil && origin.Object() != nil {
+			reachable[origin.Object()] = struct{}{}
+		}
+
+		node := cg.Nodes[fn]
+		if node == nil {
+			continue
+		}
+		for _, edge := range node.Out {
+			worklist = append(worklist, edge.Callee.Func)
+		}
+	}
+
+	reachableByName := sa.reachableNames(reachable)
+	var disagreements []*analysis.FuncInfo
+	for obj, fi := range funcs {
+		if !fi.IsUsed && !fi.UsedOnlyByBenchmarks && !fi.UsedOnlyByExamples && !fi.UnusedAfterRemoval && !fi.UsedOnlyByExcluded && sa.isReachable(obj, reachable, reachableByName) {
+			disagreements = append(disagreements, fi)
+		}
+	}
+	return disagreements
+}
@@ -0,0 +1,80 @@
+package ssa
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/715d/unusedfunc/internal/analysis"
+)
+
+func TestSSAAnalyzer_CHADisagreements(t *testing.T) {
+	const code = `package main
+
+type Shape interface{ Area() int }
+
+type Square struct{}
+
+func (Square) Area() int { return 1 }
+
+// Circle is never created, so RTA never dispatches to its Area method.
+// CHA only looks at the type hierarchy and does.
+type Circle struct{}
+
+func (Circle) Area() int { return 3 }
+
+func unused() {}
+
+func main() {
+	var s Shape = Square{}
+	_ = s.Area()
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", code, parser.ParseComments)
+	require.NoError(t, err)
+
+	pkg := &packages.Package{
+		ID:         "test",
+		Name:       "main",
+		PkgPath:    "test",
+		Syntax:     []*ast.File{file},
+		Fset:       fset,
+		TypesSizes: gotypes.SizesFor("gc", "amd64"),
+	}
+	info := &gotypes.Info{
+		Types:      make(map[ast.Expr]gotypes.TypeAndValue),
+		Defs:       make(map[*ast.Ident]gotypes.Object),
+		Uses:       make(map[*ast.Ident]gotypes.Object),
+		Selections: make(map[*ast.SelectorExpr]*gotypes.Selection),
+		Implicits:  make(map[ast.Node]gotypes.Object),
+	}
+	pkg.TypesInfo = info
+	conf := gotypes.Config{Importer: importer.Default()}
+	pkg.Types, err = conf.Check("test", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	analyzer, err := NewAnalyzer([]*packages.Package{pkg}, false)
+	require.NoError(t, err)
+
+	funcs := make(map[gotypes.Object]*analysis.FuncInfo)
+	for _, obj := range info.Defs {
+		if fn, ok := obj.(*gotypes.Func); ok {
+			funcs[fn] = analysis.NewFuncInfo(fn, pkg, analyzer.nameCache, false)
+		}
+	}
+	require.NoError(t, analyzer.AnalyzeFuncs(funcs))
+
+	var names []string
+	for _, fi := range analyzer.CHADisagreements(funcs) {
+		names = append(names, fi.Name)
+	}
+	require.Equal(t, []string{"test.Circle.Area"}, names)
+}
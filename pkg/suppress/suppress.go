@@ -7,6 +7,7 @@ import (
 	"go/token"
 	"maps"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -17,6 +18,9 @@ type Checker struct {
 
 	// fset is the file set for position calculations
 	fset *token.FileSet
+
+	// comments holds every suppression directive found, in file order
+	comments []Suppression
 }
 
 // Suppression represents a parsed suppression directive.
@@ -35,8 +39,35 @@ const (
 
 	// SuppressionLintIgnore represents //lint:ignore unusedfunc comments.
 	SuppressionLintIgnore
+
+	// SuppressionGenericNolint represents //nolint comments that name no linter.
+	SuppressionGenericNolint
+
+	// SuppressionIgnore represents //unusedfunc:ignore comments.
+	SuppressionIgnore
+
+	// SuppressionFileIgnore represents //lint:file-ignore unusedfunc
+	// comments, which suppress every function in their file.
+	SuppressionFileIgnore
 )
 
+// String returns the directive as written in comments.
+func (t SuppressionType) String() string {
+	switch t {
+	case SuppressionNolint:
+		return "nolint:unusedfunc"
+	case SuppressionLintIgnore:
+		return "lint:ignore unusedfunc"
+	case SuppressionGenericNolint:
+		return "nolint"
+	case SuppressionIgnore:
+		return "unusedfunc:ignore"
+	case SuppressionFileIgnore:
+		return "lint:file-ignore unusedfunc"
+	}
+	return fmt.Sprintf("SuppressionType(%d)", int(t))
+}
+
 // Suppression patterns for different comment styles.
 var (
 	// nolintPattern matches //nolint:unusedfunc comments
@@ -45,6 +76,12 @@ var (
 	// lintIgnorePattern matches //lint:ignore unusedfunc comments
 	lintIgnorePattern = regexp.MustCompile(`//\s*lint:ignore\s+unusedfunc(?:\s+(.+))?`)
 
+	// fileIgnorePattern matches staticcheck-style //lint:file-ignore unusedfunc comments
+	fileIgnorePattern = regexp.MustCompile(`//\s*lint:file-ignore\s+unusedfunc(?:\s+(.+))?`)
+
+	// ignorePattern matches //unusedfunc:ignore comments
+	ignorePattern = regexp.MustCompile(`//\s*unusedfunc:ignore(?:\s+(.+))?`)
+
 	// genericNolintPattern matches //nolint comments without specific linter
 	genericNolintPattern = regexp.MustCompile(`//\s*nolint(?:\s|$)`)
 
@@ -71,10 +108,18 @@ func (sc *Checker) Load(fset *token.FileSet, files []*ast.File) error {
 
 	for _, file := range files {
 		suppressionsByLine := make(map[int]*Suppression)
+		// As in staticcheck, a file-ignore directive applies to the whole
+		// file wherever it appears, though it is usually near the top.
+		var fileSuppression *Suppression
 
 		for _, commentGroup := range file.Comments {
 			for _, comment := range commentGroup.List {
 				if suppression := sc.parseComment(comment); suppression != nil {
+					sc.comments = append(sc.comments, *suppression)
+					if suppression.Type == SuppressionFileIgnore {
+						fileSuppression = suppression
+						continue
+					}
 					pos := fset.Position(comment.Pos())
 					suppressionsByLine[pos.Line] = suppression
 				}
@@ -90,11 +135,22 @@ func (sc *Checker) Load(fset *token.FileSet, files []*ast.File) error {
 
 				// Check if there's a suppression on the line immediately before this function.
 				// or on the same line as the function (Go standard behavior).
+				// The same line is that of the func keyword, where a trailing
+				// comment like `func f() { //unusedfunc:ignore` ends up, or
+				// that of the name if the signature spans several lines.
 				var suppression *Suppression
 				var exists bool
 
-				if suppression, exists = suppressionsByLine[funcPosInfo.Line-1]; !exists {
-					suppression, exists = suppressionsByLine[funcPosInfo.Line]
+				funcLine := fset.Position(funcDecl.Type.Func).Line
+				if suppression, exists = suppressionsByLine[funcLine-1]; !exists {
+					if suppression, exists = suppressionsByLine[funcLine]; !exists {
+						suppression, exists = suppressionsByLine[funcPosInfo.Line]
+					}
+				}
+				// A directive on the function itself, and its reason,
+				// take precedence over the file-wide one.
+				if !exists && fileSuppression != nil {
+					suppression, exists = fileSuppression, true
 				}
 
 				if exists {
@@ -140,11 +196,27 @@ func (sc *Checker) parseComment(comment *ast.Comment) *Suppression {
 		}
 	}
 
+	if matches := fileIgnorePattern.FindStringSubmatch(text); matches != nil {
+		return &Suppression{
+			Position: comment.Pos(),
+			Reason:   strings.TrimSpace(matches[1]),
+			Type:     SuppressionFileIgnore,
+		}
+	}
+
+	if matches := ignorePattern.FindStringSubmatch(text); matches != nil {
+		return &Suppression{
+			Position: comment.Pos(),
+			Reason:   strings.TrimSpace(matches[1]),
+			Type:     SuppressionIgnore,
+		}
+	}
+
 	if genericNolintPattern.MatchString(text) {
 		return &Suppression{
 			Position: comment.Pos(),
 			Reason:   "",
-			Type:     SuppressionNolint,
+			Type:     SuppressionGenericNolint,
 		}
 	}
 
@@ -181,9 +253,16 @@ func (sc *Checker) IsSuppressed(pos token.Pos) (bool, string) {
 	return false, ""
 }
 
+// Comments returns every suppression directive found by Load, in file
+// order, including those that do not precede a function.
+func (sc *Checker) Comments() []Suppression {
+	return slices.Clone(sc.comments)
+}
+
 // Clear clears all suppressions.
 func (sc *Checker) Clear() {
 	sc.suppressions = make(map[token.Pos]string)
+	sc.comments = nil
 }
 
 func (sc *Checker) getAllSuppressions() map[token.Pos]string {
@@ -1,6 +1,7 @@
 package suppress
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -55,10 +56,38 @@ func TestSuppressionChecker_ParseComment(t *testing.T) {
 		{
 			name:           "generic nolint",
 			comment:        "//nolint",
-			expectedType:   SuppressionNolint,
+			expectedType:   SuppressionGenericNolint,
+			expectedReason: "",
+			expectParsed:   true,
+		},
+		{
+			name:           "unusedfunc ignore",
+			comment:        "//unusedfunc:ignore",
+			expectedType:   SuppressionIgnore,
 			expectedReason: "",
 			expectParsed:   true,
 		},
+		{
+			name:           "unusedfunc ignore with reason",
+			comment:        "//unusedfunc:ignore called from assembly",
+			expectedType:   SuppressionIgnore,
+			expectedReason: "called from assembly",
+			expectParsed:   true,
+		},
+		{
+			name:           "file ignore",
+			comment:        "//lint:file-ignore unusedfunc",
+			expectedType:   SuppressionFileIgnore,
+			expectedReason: "",
+			expectParsed:   true,
+		},
+		{
+			name:           "file ignore with reason",
+			comment:        "//lint:file-ignore unusedfunc loaded by the plugin host",
+			expectedType:   SuppressionFileIgnore,
+			expectedReason: "loaded by the plugin host",
+			expectParsed:   true,
+		},
 		{
 			name:           "nolint with multiple rules",
 			comment:        "//nolint:unusedfunc,deadcode",
@@ -153,6 +182,42 @@ func Function3() {
 }`,
 			expectedCount: 2,
 		},
+		{
+			name: "trailing comment on the func line",
+			sourceCode: `package test
+
+type T struct{}
+
+func Function1() { //unusedfunc:ignore
+}
+
+func (t *T) Method() int { //nolint:unusedfunc
+	return 0
+}
+
+func Function2() {
+	_ = 1 //unusedfunc:ignore does not apply to the function
+}
+
+func Function3() {}`,
+			expectedCount: 2,
+		},
+		{
+			name: "file ignore suppresses every function",
+			sourceCode: `//lint:file-ignore unusedfunc loaded by the plugin host
+
+package test
+
+type T struct{}
+
+func Function1() {}
+
+func (t *T) Method() {}
+
+//nolint:unusedfunc
+func Function2() {}`,
+			expectedCount: 3,
+		},
 	}
 
 	for _, tt := range tests {
@@ -171,6 +236,46 @@ func Function3() {
 	}
 }
 
+// TestSuppressionChecker_Comments tests that every directive is recorded with its style.
+func TestSuppressionChecker_Comments(t *testing.T) {
+	const src = `package test
+
+//nolint:unusedfunc
+func a() {}
+
+//lint:ignore unusedfunc legacy support
+func b() {}
+
+var x = 1 //nolint
+
+//nolint:deadcode,unusedfunc
+func c() {}
+
+// regular comment
+func d() {}`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	checker := NewChecker()
+	require.NoError(t, checker.Load(fset, []*ast.File{file}))
+
+	var got []string
+	for _, c := range checker.Comments() {
+		got = append(got, fmt.Sprintf("%d %s %s", fset.Position(c.Position).Line, c.Type, c.Reason))
+	}
+	require.Equal(t, []string{
+		"3 nolint:unusedfunc ",
+		"6 lint:ignore unusedfunc legacy support",
+		"9 nolint ",
+		"11 nolint:unusedfunc ",
+	}, got)
+
+	checker.Clear()
+	require.Empty(t, checker.Comments())
+}
+
 // TestSuppressionChecker_IsSuppressed tests suppression checking with reasons.
 func TestSuppressionChecker_IsSuppressed(t *testing.T) {
 	sourceCode := `package test
@@ -236,6 +341,48 @@ func Method2() {}`
 	})
 }
 
+// TestSuppressionChecker_FileIgnore tests that a file-wide directive applies
+// only to its own file and yields to a function's own directive.
+func TestSuppressionChecker_FileIgnore(t *testing.T) {
+	const ignored = `//lint:file-ignore unusedfunc plugin entry points
+
+package test
+
+func Plain() {}
+
+//lint:ignore unusedfunc called from assembly
+func Annotated() {}`
+	const other = `package test
+
+func Other() {}`
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for name, src := range map[string]string{"ignored.go": ignored, "other.go": other} {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+
+	checker := NewChecker()
+	require.NoError(t, checker.Load(fset, files))
+
+	reasons := make(map[string]string)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				if suppressed, reason := checker.IsSuppressed(fn.Name.Pos()); suppressed {
+					reasons[fn.Name.Name] = reason
+				}
+			}
+		}
+	}
+	require.Equal(t, map[string]string{
+		"Plain":     "plugin entry points",
+		"Annotated": "called from assembly",
+	}, reasons)
+}
+
 // TestSuppressionChecker_Clear tests clearing suppressions.
 func TestSuppressionChecker_Clear(t *testing.T) {
 	sourceCode := `package test
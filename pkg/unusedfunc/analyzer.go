@@ -8,7 +8,9 @@ import (
 	"go/types"
 	"log/slog"
 	"maps"
+	"regexp"
 	goruntime "runtime"
+	"slices"
 	"strings"
 	"sync/atomic"
 
@@ -24,15 +26,104 @@ import (
 
 // AnalyzerOptions holds configuration options for the analyzer.
 type AnalyzerOptions struct {
-	SkipGenerated bool // Skip files with generated code markers.
-	Strict        bool // Report ALL unused exported functions (not just /internal).
+	SkipGenerated   bool // Skip files with generated code markers.
+	CheckGenerated  bool // Only report functions in generated files, overriding SkipGenerated.
+	Strict          bool // Report ALL unused exported functions (not just /internal).
+	MaxRTAVisits    int  // Stop reachability analysis after this many function visits (0 = unlimited).
+	ReportEmptyInit bool // Also report init functions whose body has no effect.
+	BenchmarkOnly   bool // Report exported functions reachable only from benchmarks.
+	ExampleOnly     bool // Report exported functions reachable only from examples.
+	Verify          bool // Cross-check reported functions against CHA; see Disagreements.
+
+	// UnexportedOnly trades the accuracy of the results for exported
+	// functions for speed; see ssa.Options.UnexportedOnly. Callers should
+	// only report unexported functions.
+	UnexportedOnly bool
+
+	// AssumeImpl maps interfaces to the only concrete type assumed to
+	// implement them, both as "import/path.Name". This is unsafe: methods
+	// of the other implementations may be reported although a different
+	// configuration calls them. See ssa.Options.AssumeImpl.
+	AssumeImpl map[string]string
+
+	// AssumeInterfaceUsed names interfaces, as "import/path.Name", that code
+	// outside the analyzed packages calls, e.g. a library interface consumers
+	// dispatch on. The methods of their implementations are kept.
+	AssumeInterfaceUsed []string
+
+	// K8sAware keeps the generated DeepCopy* methods of types in packages
+	// that import k8s.io/apimachinery. See ssa.Options.K8sAware.
+	K8sAware bool
+
+	// CascadeTypes reports the unused methods of exported types that no
+	// analyzed code refers to, instead of keeping their exported methods as
+	// library API. A library's users may refer to such types, so this suits
+	// code whose only users are analyzed along with it.
+	CascadeTypes bool
+
+	// AssumeRemoved names functions, as "import/path.Func", and methods, as
+	// "import/path.Type.Method", to analyze as if they were deleted. The
+	// functions only they keep alive are reported with the reason
+	// "unused once the assumed-removed functions are deleted".
+	AssumeRemoved []string
+
+	// IgnoreCompileAsserts stops compile-time interface assertions such as
+	// "var _ I = (*T)(nil)" from keeping the methods of T that I requires.
+	// See ssa.Options.IgnoreCompileAsserts.
+	IgnoreCompileAsserts bool
+
+	// DeadCalls records the calls between the reported functions; see
+	// Analyzer.DeadCalls.
+	DeadCalls bool
+
+	// Dual also computes what strict mode would report, marking the
+	// functions only the public API of library packages keeps alive with
+	// StrictOnly, from the same SSA program. See ssa.Options.Dual.
+	Dual bool
+
+	// PreciseUnnamedInterfaces keeps the methods called through an unnamed
+	// interface only on the types converted to one with the same methods.
+	// See ssa.Options.PreciseUnnamedInterfaces.
+	PreciseUnnamedInterfaces bool
+
+	// TestSupportPattern matches the import paths of test-support packages,
+	// whose unused exported functions are reported like those of internal
+	// packages. Nil disables the check.
+	TestSupportPattern *regexp.Regexp
+
+	// ReportUnimported reports the unused exported functions of library
+	// packages that no analyzed package imports, like those of internal
+	// packages. It suits a whole module whose packages are not imported
+	// from outside it.
+	ReportUnimported bool
+
+	// PackageRegex restricts the reported functions to packages whose import
+	// path matches. All packages still take part in reachability analysis,
+	// so calls from non-matching packages keep functions alive. Nil reports
+	// every target package.
+	PackageRegex *regexp.Regexp
+
+	// ExcludePackages lists import paths whose functions are not reported.
+	// The packages still take part in reachability analysis, so their calls
+	// keep the functions of other packages alive.
+	ExcludePackages []string
+
+	// ReportExcludedOnlyUsers also reports the functions whose every caller
+	// lives in one of ExcludePackages, directly or through other such
+	// functions, with the reason "used only by excluded packages".
+	ReportExcludedOnlyUsers bool
 }
 
 // Analyzer orchestrates the method analysis process using SSA.
 type Analyzer struct {
-	suppressions *suppress.Checker
-	nameCache    *analysis.NameCache
-	opts         AnalyzerOptions
+	suppressions  *suppress.Checker
+	nameCache     *analysis.NameCache
+	opts          AnalyzerOptions
+	implements    map[string][]string
+	emptyInits    []UnusedFunction
+	disagreements []UnusedFunction
+	deadCalls     map[string][]string
+	truncated     bool
 }
 
 // NewAnalyzer creates a new analyzer with the given options.
@@ -59,26 +150,136 @@ func (a *Analyzer) Analyze(pkgs []*packages.Package) (map[types.Object]*analysis
 	// Step 2: Scan assembly files for function implementations and calls.
 	assemblyInfo := a.scanAssemblyFiles(pkgs)
 
+	var unreferencedTypes map[*types.TypeName]bool
+	if a.opts.CascadeTypes {
+		unreferencedTypes = analysis.UnreferencedTypes(pkgs)
+	}
+	var unimported map[string]bool
+	if a.opts.ReportUnimported {
+		unimported = analysis.UnimportedPackages(pkgs)
+	}
+
 	// Step 3: Create SSA analyzer and analyze all functions.
-	ssaAnalyzer, err := ssa.NewAnalyzer(pkgs, a.opts.Strict)
+	var excluded []string
+	if a.opts.ReportExcludedOnlyUsers {
+		excluded = a.opts.ExcludePackages
+	}
+	ssaAnalyzer, err := ssa.NewAnalyzerWithOptions(pkgs, ssa.Options{
+		Strict:                   a.opts.Strict,
+		MaxRTAVisits:             a.opts.MaxRTAVisits,
+		BenchmarkOnly:            a.opts.BenchmarkOnly,
+		ExampleOnly:              a.opts.ExampleOnly,
+		TestSupportPattern:       a.opts.TestSupportPattern,
+		UnimportedPackages:       unimported,
+		UnexportedOnly:           a.opts.UnexportedOnly,
+		AssumeImpl:               a.opts.AssumeImpl,
+		AssumeInterfaceUsed:      a.opts.AssumeInterfaceUsed,
+		K8sAware:                 a.opts.K8sAware,
+		UnreferencedTypes:        unreferencedTypes,
+		AssumeRemoved:            a.opts.AssumeRemoved,
+		ExcludedPackages:         excluded,
+		IgnoreCompileAsserts:     a.opts.IgnoreCompileAsserts,
+		Dual:                     a.opts.Dual,
+		PreciseUnnamedInterfaces: a.opts.PreciseUnnamedInterfaces,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("create SSA analyzer: %w", err)
 	}
 
 	// Step 4: Get all functions from packages.
 	funcs := a.collectFunctions(pkgs, assemblyInfo)
+	recordInstantiations(pkgs, funcs)
+	markUnreferencedTypeMethods(funcs, unreferencedTypes)
+	for _, fi := range funcs {
+		fi.IsInUnimported = fi.Package != nil && unimported[fi.Package.PkgPath]
+	}
 
 	// Step 5: Run SSA analysis.
 	if err := ssaAnalyzer.AnalyzeFuncs(funcs); err != nil {
 		return nil, fmt.Errorf("SSA analysis failed: %w", err)
 	}
+	a.implements = ssaAnalyzer.Implements()
+	a.truncated = ssaAnalyzer.Truncated()
 
-	// Step 5: Check suppressions and mark suppressed functions.
+	// Step 6: Check suppressions and mark suppressed functions.
 	a.checkSuppressions(funcs)
 
+	if a.opts.Verify {
+		a.disagreements = nil
+		for _, fi := range ssaAnalyzer.CHADisagreements(funcs) {
+			if !fi.ShouldReport() {
+				continue
+			}
+			a.disagreements = append(a.disagreements, UnusedFunction{
+				Name:        fi.Name,
+				Position:    fi.Package.Fset.Position(fi.DeclarationPos),
+				Reason:      "unreachable by RTA but reachable by CHA",
+				Package:     fi.Package.PkgPath,
+				PackageName: fi.Package.Name,
+			})
+		}
+		slices.SortFunc(a.disagreements, func(x, y UnusedFunction) int {
+			return strings.Compare(x.Name, y.Name)
+		})
+	}
+
+	if a.opts.ReportEmptyInit {
+		a.emptyInits = a.findEmptyInits(pkgs)
+	}
+
+	// Step 7: Narrow the results to the requested packages.
+	if re := a.opts.PackageRegex; re != nil {
+		maps.DeleteFunc(funcs, func(_ types.Object, fi *analysis.FuncInfo) bool {
+			return fi.Package == nil || !re.MatchString(fi.Package.PkgPath)
+		})
+		a.emptyInits = slices.DeleteFunc(a.emptyInits, func(f UnusedFunction) bool {
+			return !re.MatchString(f.Package)
+		})
+		a.disagreements = slices.DeleteFunc(a.disagreements, func(f UnusedFunction) bool {
+			return !re.MatchString(f.Package)
+		})
+	}
+
+	if len(a.opts.ExcludePackages) > 0 {
+		maps.DeleteFunc(funcs, func(_ types.Object, fi *analysis.FuncInfo) bool {
+			return fi.Package != nil && slices.Contains(a.opts.ExcludePackages, fi.Package.PkgPath)
+		})
+		a.emptyInits = slices.DeleteFunc(a.emptyInits, func(f UnusedFunction) bool {
+			return slices.Contains(a.opts.ExcludePackages, f.Package)
+		})
+		a.disagreements = slices.DeleteFunc(a.disagreements, func(f UnusedFunction) bool {
+			return slices.Contains(a.opts.ExcludePackages, f.Package)
+		})
+	}
+
+	if a.opts.DeadCalls {
+		a.deadCalls = deadCalls(ssaAnalyzer, funcs)
+	}
+
 	return funcs, nil
 }
 
+// deadCalls maps the name of each reported function to the sorted names of
+// the reported functions it calls.
+func deadCalls(sa *ssa.Analyzer, funcs map[types.Object]*analysis.FuncInfo) map[string][]string {
+	reported := make(map[types.Object]bool)
+	for obj, fi := range funcs {
+		if fi.ShouldReport() {
+			reported[obj] = true
+		}
+	}
+
+	calls := make(map[string][]string)
+	for caller, callees := range sa.StaticCalls(reported) {
+		name := funcs[caller].Name
+		for _, callee := range callees {
+			calls[name] = append(calls[name], funcs[callee].Name)
+		}
+		slices.Sort(calls[name])
+	}
+	return calls
+}
+
 func (a *Analyzer) collectFunctions(pkgs []*packages.Package, assemblyInfo map[string]*assembly.Info) map[types.Object]*analysis.FuncInfo {
 	// Lock-free concurrency pattern: pre-allocate results slice with exact size.
 	// Each goroutine writes to its own index, eliminating need for locks/mutexes.
@@ -96,18 +297,34 @@ func (a *Analyzer) collectFunctions(pkgs []*packages.Package, assemblyInfo map[s
 		wg.Go(func() error {
 			result := make(map[types.Object]*analysis.FuncInfo)
 
-			// Filter out generated files if requested.
+			// Filter out generated files if requested, or the others with
+			// CheckGenerated. Their functions take part in the analysis but
+			// are not collected.
 			filteredFiles := pkg.Syntax
-			if a.opts.SkipGenerated {
+			skipped := make(map[*token.File]bool)
+			if a.opts.SkipGenerated || a.opts.CheckGenerated {
 				filteredFiles = nil
 				for _, file := range pkg.Syntax {
-					if file != nil && !a.isGeneratedFile(pkg.Fset, file) {
-						filteredFiles = append(filteredFiles, file)
+					if file == nil {
+						continue
+					}
+					if a.skipFile(pkg.Fset, file) {
+						skipped[pkg.Fset.File(file.Pos())] = true
+						continue
 					}
+					filteredFiles = append(filteredFiles, file)
 				}
 			}
 
 			declMap := buildFuncDeclMapFromFiles(filteredFiles)
+			cgoExports := make(map[string]bool)
+			for _, file := range filteredFiles {
+				for _, name := range runtime.CGoExportedFunctions(file) {
+					cgoExports[name] = true
+				}
+			}
+
+			testSupport := a.opts.TestSupportPattern != nil && a.opts.TestSupportPattern.MatchString(pkg.PkgPath)
 
 			scope := pkg.Types.Scope()
 			for _, name := range scope.Names() {
@@ -121,8 +338,16 @@ func (a *Analyzer) collectFunctions(pkgs []*packages.Package, assemblyInfo map[s
 					if fn.Name() == "" {
 						continue
 					}
+					if skipped[pkg.Fset.File(fn.Pos())] {
+						continue
+					}
 					funcInfo := analysis.NewFuncInfo(fn, pkg, a.nameCache, a.opts.Strict)
+					funcInfo.IsInTestSupport = testSupport
 					a.detectRuntimeDirectives(funcInfo, declMap)
+					if cgoExports[fn.Name()] {
+						funcInfo.HasRuntimeDirective = true
+						funcInfo.HasCGoExport = true
+					}
 					// Check if this function has assembly implementation or is called from assembly.
 					if assemblyInfo[pkg.PkgPath] != nil {
 						_, ok := assemblyInfo[pkg.PkgPath].ImplementedFunctions[fn.Name()]
@@ -138,7 +363,11 @@ func (a *Analyzer) collectFunctions(pkgs []*packages.Package, assemblyInfo map[s
 
 						for i := range named.NumMethods() {
 							method := named.Method(i)
+							if skipped[pkg.Fset.File(method.Pos())] {
+								continue
+							}
 							funcInfo := analysis.NewFuncInfo(method, pkg, a.nameCache, a.opts.Strict)
+							funcInfo.IsInTestSupport = testSupport
 							a.detectRuntimeDirectives(funcInfo, declMap)
 							// Check if this method has assembly implementation or is called from assembly.
 							if assemblyInfo[pkg.PkgPath] != nil {
@@ -169,6 +398,170 @@ func (a *Analyzer) collectFunctions(pkgs []*packages.Package, assemblyInfo map[s
 	return finalFuncs
 }
 
+// Implements returns the interface implementation graph computed by the last
+// call to Analyze, mapping interface names to implementing type names.
+func (a *Analyzer) Implements() map[string][]string {
+	return a.implements
+}
+
+// Truncated reports whether reachability analysis stopped at MaxRTAVisits
+// during the last call to Analyze, so that some of the reported functions
+// may be used.
+func (a *Analyzer) Truncated() bool {
+	return a.truncated
+}
+
+// Disagreements returns the reported functions of the last call to Analyze
+// that Class Hierarchy Analysis finds reachable, i.e. potential false
+// positives. It is only populated when Verify is set.
+func (a *Analyzer) Disagreements() []UnusedFunction {
+	return a.disagreements
+}
+
+// DeadCalls returns the calls between the functions reported by the last
+// call to Analyze, mapping the name of each caller to the sorted names of its
+// callees. Only static calls and references to functions as values count.
+// It is only populated when DeadCalls is set.
+func (a *Analyzer) DeadCalls() map[string][]string {
+	return a.deadCalls
+}
+
+// EmptyInits returns the init functions without effect found by the last call
+// to Analyze. It is only populated when ReportEmptyInit is set.
+func (a *Analyzer) EmptyInits() []UnusedFunction {
+	return a.emptyInits
+}
+
+// markUnreferencedTypeMethods flags the methods of the types in unreferenced.
+func markUnreferencedTypeMethods(funcs map[types.Object]*analysis.FuncInfo, unreferenced map[*types.TypeName]bool) {
+	if len(unreferenced) == 0 {
+		return
+	}
+	for obj, fi := range funcs {
+		fn, ok := obj.(*types.Func)
+		if !ok || fn.Signature().Recv() == nil {
+			continue
+		}
+		t := fn.Signature().Recv().Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := types.Unalias(t).(*types.Named); ok && unreferenced[named.Origin().Obj()] {
+			fi.OnUnreferencedType = true
+		}
+	}
+}
+
+// recordInstantiations records on each generic function, and on each method of
+// a generic type, the concrete type arguments it is instantiated with anywhere
+// in pkgs. Instantiations inside generic code, whose arguments are still type
+// parameters, are ignored.
+func recordInstantiations(pkgs []*packages.Package, funcs map[types.Object]*analysis.FuncInfo) {
+	methodsByType := make(map[*types.TypeName][]*analysis.FuncInfo)
+	for obj, fi := range funcs {
+		fn, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+		recv := fn.Signature().Recv()
+		if recv == nil {
+			continue
+		}
+		recvType := recv.Type()
+		if ptr, ok := recvType.(*types.Pointer); ok {
+			recvType = ptr.Elem()
+		}
+		if named, ok := recvType.(*types.Named); ok && named.TypeParams().Len() > 0 {
+			origin := named.Origin().Obj()
+			methodsByType[origin] = append(methodsByType[origin], fi)
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if pkg == nil || pkg.TypesInfo == nil {
+			continue
+		}
+		for ident, inst := range pkg.TypesInfo.Instances {
+			if hasTypeParam(inst.TypeArgs) {
+				continue
+			}
+			switch obj := pkg.TypesInfo.Uses[ident].(type) {
+			case *types.Func:
+				if fi := funcs[obj.Origin()]; fi != nil {
+					fi.AddInstantiation(inst.TypeArgs)
+				}
+			case *types.TypeName:
+				for _, fi := range methodsByType[obj] {
+					fi.AddInstantiation(inst.TypeArgs)
+				}
+			}
+		}
+	}
+}
+
+// hasTypeParam reports whether any of the type arguments mentions a type parameter.
+func hasTypeParam(typeArgs *types.TypeList) bool {
+	for i := range typeArgs.Len() {
+		if mentionsTypeParam(typeArgs.At(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionsTypeParam reports whether t mentions a type parameter anywhere in
+// its structure, such as func(T) or struct{ x []T }.
+func mentionsTypeParam(t types.Type) bool {
+	switch t := types.Unalias(t).(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return mentionsTypeParam(t.Elem())
+	case *types.Slice:
+		return mentionsTypeParam(t.Elem())
+	case *types.Array:
+		return mentionsTypeParam(t.Elem())
+	case *types.Chan:
+		return mentionsTypeParam(t.Elem())
+	case *types.Map:
+		return mentionsTypeParam(t.Key()) || mentionsTypeParam(t.Elem())
+	case *types.Named:
+		return hasTypeParam(t.TypeArgs())
+	case *types.Signature:
+		return mentionsTypeParam(t.Params()) || mentionsTypeParam(t.Results())
+	case *types.Tuple:
+		for v := range t.Variables() {
+			if mentionsTypeParam(v.Type()) {
+				return true
+			}
+		}
+	case *types.Struct:
+		for field := range t.Fields() {
+			if mentionsTypeParam(field.Type()) {
+				return true
+			}
+		}
+	case *types.Interface:
+		for method := range t.ExplicitMethods() {
+			if mentionsTypeParam(method.Type()) {
+				return true
+			}
+		}
+		for embedded := range t.EmbeddedTypes() {
+			if mentionsTypeParam(embedded) {
+				return true
+			}
+		}
+	case *types.Union:
+		for term := range t.Terms() {
+			if mentionsTypeParam(term.Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // loadSuppressions loads suppression comments from all files in the given packages
 func (a *Analyzer) loadSuppressions(pkgs []*packages.Package) error {
 	// Clear any existing suppressions.
@@ -209,10 +602,12 @@ func (a *Analyzer) checkSuppressions(funcs map[types.Object]*analysis.FuncInfo)
 	}
 }
 
-// funcDeclMap holds a pre-built map of function declarations per package
-type funcDeclMap map[string]*ast.FuncDecl
+// funcDeclMap holds a pre-built map of function declarations per package,
+// keyed by the position of the declared name. Methods of different types may
+// share a name, so names alone are not unique.
+type funcDeclMap map[token.Pos]*ast.FuncDecl
 
-// buildFuncDeclMapFromFiles builds a map of function name -> FuncDecl for quick lookup from a list of files.
+// buildFuncDeclMapFromFiles builds a map of name position -> FuncDecl for quick lookup from a list of files.
 func buildFuncDeclMapFromFiles(files []*ast.File) funcDeclMap {
 	declMap := make(funcDeclMap)
 	for _, file := range files {
@@ -221,13 +616,24 @@ func buildFuncDeclMapFromFiles(files []*ast.File) funcDeclMap {
 		}
 		for _, decl := range file.Decls {
 			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name != nil {
-				declMap[fn.Name.Name] = fn
+				declMap[fn.Name.Pos()] = fn
 			}
 		}
 	}
 	return declMap
 }
 
+// skipFile reports whether the functions of file are left out of the
+// results: generated files with SkipGenerated, and the files that are not
+// generated with CheckGenerated.
+func (a *Analyzer) skipFile(fset *token.FileSet, file *ast.File) bool {
+	generated := a.isGeneratedFile(fset, file)
+	if a.opts.CheckGenerated {
+		return !generated
+	}
+	return a.opts.SkipGenerated && generated
+}
+
 // isGeneratedFile checks if a file contains generated code markers.
 func (a *Analyzer) isGeneratedFile(fset *token.FileSet, file *ast.File) bool {
 	if file == nil || fset == nil {
@@ -263,10 +669,8 @@ func (a *Analyzer) detectRuntimeDirectives(funcInfo *analysis.FuncInfo, declMap
 		return
 	}
 
-	funcName := funcInfo.Object.Name()
-
 	// Direct lookup instead of AST walk.
-	if fn, exists := declMap[funcName]; exists {
+	if fn, exists := declMap[funcInfo.Object.Pos()]; exists {
 		directive := runtime.HasRuntimeDirective(fn)
 		if directive.Valid {
 			funcInfo.HasRuntimeDirective = true
@@ -274,6 +678,9 @@ func (a *Analyzer) detectRuntimeDirectives(funcInfo *analysis.FuncInfo, declMap
 			if directive.Type == runtime.DirectiveCGoExport {
 				funcInfo.HasCGoExport = true
 			}
+			if directive.Type == runtime.DirectiveWasmExport {
+				funcInfo.HasWasmExport = true
+			}
 		}
 	}
 }
@@ -1,7 +1,12 @@
 package unusedfunc
 
 import (
+	"context"
 	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -129,3 +134,365 @@ func TestAnalyzer_Analyze(t *testing.T) {
 		})
 	}
 }
+
+// TestAnalyzer_AnalyzeDeterministic runs the analysis repeatedly on code that
+// relies on cross-package interface dispatch and type assertions, and checks
+// that every run reports the same functions.
+func TestAnalyzer_AnalyzeDeterministic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping repeated analysis in short mode")
+	}
+
+	const runs = 10
+	for _, dir := range []string{
+		"interface-dispatch-cross-package",
+		"interface-context-value",
+	} {
+		t.Run(dir, func(t *testing.T) {
+			pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+				Packages: []string{"./..."},
+				Dir:      filepath.Join("..", "..", "testdata", dir),
+			})
+			require.NoError(t, err)
+
+			var first []string
+			for i := range runs {
+				funcs, err := NewAnalyzer(AnalyzerOptions{}).Analyze(pkgs)
+				require.NoError(t, err)
+
+				var reported []string
+				for _, f := range funcs {
+					if f.ShouldReport() {
+						reported = append(reported, f.Name)
+					}
+				}
+				slices.Sort(reported)
+
+				if i == 0 {
+					first = reported
+					continue
+				}
+				require.Equal(t, first, reported, "run %d reported different functions", i)
+			}
+		})
+	}
+}
+
+// TestAnalyzer_AnalyzePackageRegex checks that PackageRegex narrows the
+// reported functions without changing what is reachable.
+func TestAnalyzer_AnalyzePackageRegex(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping package loading in short mode")
+	}
+
+	pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"./..."},
+		Dir:      filepath.Join("..", "..", "testdata", "interface-context-value"),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		regex    string
+		expected []string
+	}{
+		{
+			// Greet is only called from the handler package, which is
+			// filtered out of the report but still analyzed.
+			name:  "matching_package",
+			regex: `/greeting$`,
+			expected: []string{
+				"example.com/project/greeting.English.unusedEnglishHelper",
+				"example.com/project/greeting.unusedGreetingHelper",
+			},
+		},
+		{
+			name:     "other_package",
+			regex:    `/handler$`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs, err := NewAnalyzer(AnalyzerOptions{
+				PackageRegex: regexp.MustCompile(tt.regex),
+			}).Analyze(pkgs)
+			require.NoError(t, err)
+
+			var reported []string
+			for _, f := range funcs {
+				require.Regexp(t, tt.regex, f.Package.PkgPath)
+				if f.ShouldReport() {
+					reported = append(reported, f.Name)
+				}
+			}
+			slices.Sort(reported)
+			require.Equal(t, tt.expected, reported)
+		})
+	}
+}
+
+// TestAnalyzer_AnalyzeGenerated checks which files' functions are reported
+// with SkipGenerated and CheckGenerated.
+func TestAnalyzer_AnalyzeGenerated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping package loading in short mode")
+	}
+
+	pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"./..."},
+		Dir:      filepath.Join("..", "..", "testdata", "wire-gen-stale"),
+	})
+	require.NoError(t, err)
+
+	const pkg = "github.com/715d/unusedfunc/testdata/wire-gen-stale."
+	tests := []struct {
+		name     string
+		opts     AnalyzerOptions
+		expected []string
+	}{
+		{
+			name:     "all_files",
+			expected: []string{pkg + "injectLegacy", pkg + "newLegacyStore", pkg + "provideCache"},
+		},
+		{
+			name:     "skip_generated",
+			opts:     AnalyzerOptions{SkipGenerated: true},
+			expected: []string{pkg + "newLegacyStore"},
+		},
+		{
+			name:     "check_generated",
+			opts:     AnalyzerOptions{SkipGenerated: true, CheckGenerated: true},
+			expected: []string{pkg + "injectLegacy", pkg + "provideCache"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs, err := NewAnalyzer(tt.opts).Analyze(pkgs)
+			require.NoError(t, err)
+
+			var reported []string
+			for _, f := range funcs {
+				if f.ShouldReport() {
+					reported = append(reported, f.Name)
+				}
+			}
+			slices.Sort(reported)
+			require.Equal(t, tt.expected, reported)
+		})
+	}
+}
+
+// TestAnalyzer_AnalyzeTestSupportPattern checks that exported functions of
+// test-support packages are reported unless a test uses them.
+func TestAnalyzer_AnalyzeTestSupportPattern(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping package loading in short mode")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":               "module example.com/support\n\ngo 1.24\n",
+		"testutil/testutil.go": "package testutil\n\nfunc NewFixture() int { return 1 }\n\nfunc StaleFixture() int { return 2 }\n",
+		"app/app.go":           "package app\n\nfunc Run() int { return 0 }\n",
+		"app/app_test.go":      "package app\n\nimport \"example.com/support/testutil\"\n\nvar fixture = Run() + testutil.NewFixture()\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"./..."},
+		Dir:      dir,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		pattern  *regexp.Regexp
+		expected []string
+	}{
+		{
+			name:     "no_pattern",
+			expected: nil,
+		},
+		{
+			name:     "matching_pattern",
+			pattern:  regexp.MustCompile(`/testutil$`),
+			expected: []string{"example.com/support/testutil.StaleFixture"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs, err := NewAnalyzer(AnalyzerOptions{
+				TestSupportPattern: tt.pattern,
+			}).Analyze(pkgs)
+			require.NoError(t, err)
+
+			var reported []string
+			for _, f := range funcs {
+				if f.ShouldReport() {
+					reported = append(reported, f.Name)
+				}
+			}
+			slices.Sort(reported)
+			require.Equal(t, tt.expected, reported)
+		})
+	}
+}
+
+func TestAnalyzer_AnalyzeReportUnimported(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping package loading in short mode")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module example.com/unimported\n\ngo 1.24\n",
+		"main.go":          "package main\n\nimport \"example.com/unimported/lib\"\n\nfunc main() { lib.Used() }\n",
+		"lib/lib.go":       "package lib\n\nfunc Used() {}\n\nfunc Unused() {}\n",
+		"orphan/orphan.go": "package orphan\n\nfunc Unused() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"./..."},
+		Dir:      dir,
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		report   bool
+		expected []string
+	}{
+		{name: "disabled", expected: nil},
+		{name: "enabled", report: true, expected: []string{"example.com/unimported/orphan.Unused"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs, err := NewAnalyzer(AnalyzerOptions{ReportUnimported: tt.report}).Analyze(pkgs)
+			require.NoError(t, err)
+
+			var reported []string
+			for _, f := range funcs {
+				if f.ShouldReport() {
+					reported = append(reported, f.Name)
+				}
+			}
+			slices.Sort(reported)
+			require.Equal(t, tt.expected, reported)
+		})
+	}
+}
+
+func TestAnalyzer_AnalyzeExcludedOnlyUsers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping package loading in short mode")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                "module example.com/rm\n\ngo 1.24\n",
+		"main.go":               "package main\n\nimport (\n\t\"example.com/rm/internal/util\"\n\t\"example.com/rm/legacy\"\n)\n\nfunc main() { legacy.Import(); util.Shared() }\n",
+		"legacy/legacy.go":      "package legacy\n\nimport \"example.com/rm/internal/util\"\n\nvar registered = util.Register()\n\nfunc Import() { util.OnlyLegacy(); util.Shared() }\n\nfunc unused() {}\n",
+		"internal/util/util.go": "package util\n\nfunc Shared() {}\n\nfunc OnlyLegacy() {}\n\nfunc Register() bool { return true }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"./..."},
+		Dir:      dir,
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		report   bool
+		expected []string
+	}{
+		{
+			name: "excluded package is not reported",
+		},
+		{
+			name:   "functions only excluded packages use are reported",
+			report: true,
+			expected: []string{
+				"example.com/rm/internal/util.OnlyLegacy",
+				"example.com/rm/internal/util.Register",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			funcs, err := NewAnalyzer(AnalyzerOptions{
+				ExcludePackages:         []string{"example.com/rm/legacy"},
+				ReportExcludedOnlyUsers: tt.report,
+			}).Analyze(pkgs)
+			require.NoError(t, err)
+
+			var reported []string
+			for _, f := range funcs {
+				if f.ShouldReport() {
+					require.True(t, f.UsedOnlyByExcluded, "function %s", f.Name)
+					reported = append(reported, f.Name)
+				}
+			}
+			slices.Sort(reported)
+			require.Equal(t, tt.expected, reported)
+		})
+	}
+}
+
+func TestMentionsTypeParam(t *testing.T) {
+	tparam := types.NewTypeParam(types.NewTypeName(0, nil, "T", nil), types.NewInterfaceType(nil, nil))
+	tuple := func(elems ...types.Type) *types.Tuple {
+		vars := make([]*types.Var, 0, len(elems))
+		for _, typ := range elems {
+			vars = append(vars, types.NewParam(0, nil, "", typ))
+		}
+		return types.NewTuple(vars...)
+	}
+	method := func(sig *types.Signature) *types.Func {
+		return types.NewFunc(0, nil, "M", sig)
+	}
+	intType := types.Typ[types.Int]
+
+	tests := []struct {
+		name     string
+		typ      types.Type
+		expected bool
+	}{
+		{"basic", intType, false},
+		{"type parameter", tparam, true},
+		{"slice", types.NewSlice(tparam), true},
+		{"map value", types.NewMap(intType, tparam), true},
+		{"tuple", tuple(intType, tparam), true},
+		{"tuple without", tuple(intType), false},
+		{"signature parameter", types.NewSignatureType(nil, nil, nil, tuple(tparam), nil, false), true},
+		{"signature result", types.NewSignatureType(nil, nil, nil, nil, tuple(types.NewPointer(tparam)), false), true},
+		{"signature without", types.NewSignatureType(nil, nil, nil, tuple(intType), tuple(intType), false), false},
+		{"struct field", types.NewStruct([]*types.Var{types.NewField(0, nil, "x", types.NewSlice(tparam), false)}, nil), true},
+		{"struct without", types.NewStruct([]*types.Var{types.NewField(0, nil, "x", intType, false)}, nil), false},
+		{"interface method", types.NewInterfaceType([]*types.Func{method(types.NewSignatureType(nil, nil, nil, tuple(tparam), nil, false))}, nil), true},
+		{"interface embedded", types.NewInterfaceType(nil, []types.Type{types.NewUnion([]*types.Term{types.NewTerm(false, tparam)})}), true},
+		{"interface without", types.NewInterfaceType([]*types.Func{method(types.NewSignatureType(nil, nil, nil, tuple(intType), nil, false))}, nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, mentionsTypeParam(tt.typ))
+		})
+	}
+}
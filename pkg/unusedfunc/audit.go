@@ -0,0 +1,70 @@
+package unusedfunc
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/715d/unusedfunc/pkg/suppress"
+)
+
+// SuppressionComment is a suppression directive found in the source.
+type SuppressionComment struct {
+	Position token.Position `json:"position"`
+	// Style is the directive as written: "nolint:unusedfunc",
+	// "lint:ignore unusedfunc", "unusedfunc:ignore",
+	// "lint:file-ignore unusedfunc" or a bare "nolint".
+	Style  string `json:"style"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// AuditSuppressions returns the suppression directives in the files of pkgs,
+// whether or not they suppress a function, sorted by position. It does not
+// analyze the packages. A file shared by several packages, like a package
+// and its test variant, is reported once.
+func AuditSuppressions(pkgs []*packages.Package) ([]SuppressionComment, error) {
+	var fset *token.FileSet
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		if pkg == nil || pkg.Fset == nil {
+			continue
+		}
+		fset = pkg.Fset
+		files = append(files, pkg.Syntax...)
+	}
+	if fset == nil {
+		return nil, nil
+	}
+
+	checker := suppress.NewChecker()
+	if err := checker.Load(fset, files); err != nil {
+		return nil, fmt.Errorf("load suppressions: %w", err)
+	}
+
+	seen := make(map[token.Position]bool)
+	var comments []SuppressionComment
+	for _, s := range checker.Comments() {
+		pos := fset.Position(s.Position)
+		if seen[pos] {
+			continue
+		}
+		seen[pos] = true
+		comments = append(comments, SuppressionComment{
+			Position: pos,
+			Style:    s.Type.String(),
+			Reason:   s.Reason,
+		})
+	}
+	slices.SortFunc(comments, func(a, b SuppressionComment) int {
+		return cmp.Or(
+			cmp.Compare(a.Position.Filename, b.Position.Filename),
+			cmp.Compare(a.Position.Line, b.Position.Line),
+			cmp.Compare(a.Position.Column, b.Position.Column),
+		)
+	})
+	return comments, nil
+}
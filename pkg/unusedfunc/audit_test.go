@@ -0,0 +1,39 @@
+package unusedfunc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestAuditSuppressions(t *testing.T) {
+	const src = `package p
+
+//lint:ignore unusedfunc kept for plugins
+func a() {}
+
+//nolint:unusedfunc
+func b() {}
+
+var x = 1 //nolint
+`
+	pkg := typeCheckTestPackage(t, "example.com/p", src)
+	// A test variant shares the files of the package.
+	variant := *pkg
+	variant.ID = "example.com/p [example.com/p.test]"
+
+	comments, err := AuditSuppressions([]*packages.Package{pkg, &variant})
+	require.NoError(t, err)
+
+	var got []string
+	for _, c := range comments {
+		got = append(got, fmt.Sprintf("%s %s %s", c.Position, c.Style, c.Reason))
+	}
+	require.Equal(t, []string{
+		"p.go:3:1 lint:ignore unusedfunc kept for plugins",
+		"p.go:6:1 nolint:unusedfunc ",
+		"p.go:9:11 nolint ",
+	}, got)
+}
@@ -0,0 +1,26 @@
+package unusedfunc
+
+// Diff compares the findings of two runs by function name. It returns the
+// findings of current that are absent from base, and the findings of base
+// that no longer appear in current, both in their original order. Positions
+// are ignored so that unrelated edits moving code around do not show up as
+// changes.
+func Diff(base, current []UnusedFunction) (added, removed []UnusedFunction) {
+	baseNames := make(map[string]bool, len(base))
+	for _, f := range base {
+		baseNames[f.Name] = true
+	}
+	currentNames := make(map[string]bool, len(current))
+	for _, f := range current {
+		currentNames[f.Name] = true
+		if !baseNames[f.Name] {
+			added = append(added, f)
+		}
+	}
+	for _, f := range base {
+		if !currentNames[f.Name] {
+			removed = append(removed, f)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,55 @@
+package unusedfunc
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	at := func(name string, line int) UnusedFunction {
+		return UnusedFunction{Name: name, Position: token.Position{Filename: "a.go", Line: line}}
+	}
+
+	tests := []struct {
+		name        string
+		base        []UnusedFunction
+		current     []UnusedFunction
+		wantAdded   []UnusedFunction
+		wantRemoved []UnusedFunction
+	}{
+		{
+			name:    "no_base",
+			current: []UnusedFunction{at("p.a", 1), at("p.b", 2)},
+			wantAdded: []UnusedFunction{
+				at("p.a", 1), at("p.b", 2),
+			},
+		},
+		{
+			name:    "unchanged",
+			base:    []UnusedFunction{at("p.a", 1)},
+			current: []UnusedFunction{at("p.a", 1)},
+		},
+		{
+			name:    "moved_is_not_a_change",
+			base:    []UnusedFunction{at("p.a", 1)},
+			current: []UnusedFunction{at("p.a", 40)},
+		},
+		{
+			name:        "added_and_removed",
+			base:        []UnusedFunction{at("p.a", 1), at("p.b", 2)},
+			current:     []UnusedFunction{at("p.b", 2), at("p.c", 3)},
+			wantAdded:   []UnusedFunction{at("p.c", 3)},
+			wantRemoved: []UnusedFunction{at("p.a", 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := Diff(tt.base, tt.current)
+			require.Equal(t, tt.wantAdded, added)
+			require.Equal(t, tt.wantRemoved, removed)
+		})
+	}
+}
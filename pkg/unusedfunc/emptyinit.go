@@ -0,0 +1,143 @@
+package unusedfunc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// findEmptyInits returns the init functions of pkgs whose bodies have no
+// effect: they call nothing and assign no package state. init is always an
+// entry point, so it is never unused, but a no-op init is dead code all the
+// same. A package may declare several init functions, so each is named
+// after its position, e.g. "example.com/p.init#p.go:12".
+func (a *Analyzer) findEmptyInits(pkgs []*packages.Package) []UnusedFunction {
+	var found []UnusedFunction
+	for _, pkg := range pkgs {
+		if pkg == nil || pkg.TypesInfo == nil || pkg.Types == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			if file == nil || a.skipFile(pkg.Fset, file) {
+				continue
+			}
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv != nil || fn.Name.Name != "init" || fn.Body == nil {
+					continue
+				}
+				if hasEffect(fn.Body, pkg.TypesInfo, pkg.Types.Scope()) {
+					continue
+				}
+				suppressed, _ := a.suppressions.IsSuppressed(fn.Name.Pos())
+				pos := pkg.Fset.Position(fn.Name.Pos())
+				found = append(found, UnusedFunction{
+					Name:        fmt.Sprintf("%s.init#%s:%d", pkg.PkgPath, filepath.Base(pos.Filename), pos.Line),
+					Position:    pos,
+					Reason:      EmptyInitReason,
+					Suppressed:  suppressed,
+					Package:     pkg.PkgPath,
+					PackageName: pkg.Name,
+				})
+			}
+		}
+	}
+	return found
+}
+
+// hasEffect reports whether executing body could be observed outside it.
+// Branches of an if with a constant condition that are never taken are
+// ignored. Otherwise the check is conservative: anything that is not
+// provably local, such as a call, a channel operation or a write through a
+// pointer, counts as an effect.
+func hasEffect(body *ast.BlockStmt, info *types.Info, pkgScope *types.Scope) bool {
+	effect := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if effect {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			effect = isEffectfulCall(n, info)
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if writesNonLocal(lhs, info, pkgScope) {
+					effect = true
+				}
+			}
+		case *ast.IncDecStmt:
+			effect = writesNonLocal(n.X, info, pkgScope)
+		case *ast.UnaryExpr:
+			effect = n.Op == token.ARROW
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				effect = writesNonLocal(n.Key, info, pkgScope) || writesNonLocal(n.Value, info, pkgScope)
+			}
+			if !effect {
+				// Ranging over a channel receives from it, and ranging over
+				// a function calls it.
+				switch info.TypeOf(n.X).Underlying().(type) {
+				case *types.Chan, *types.Signature:
+					effect = true
+				}
+			}
+		case *ast.IfStmt:
+			// Skip the dead branch of an if with a constant condition.
+			if tv, ok := info.Types[n.Cond]; ok && tv.Value != nil && tv.Value.Kind() == constant.Bool {
+				live := ast.Stmt(n.Body)
+				if !constant.BoolVal(tv.Value) {
+					live = n.Else
+				}
+				effect = (n.Init != nil && hasEffect(&ast.BlockStmt{List: []ast.Stmt{n.Init}}, info, pkgScope)) ||
+					(live != nil && hasEffect(&ast.BlockStmt{List: []ast.Stmt{live}}, info, pkgScope))
+				return false
+			}
+		case *ast.ForStmt:
+			// An endless loop blocks initialization forever.
+			effect = n.Cond == nil
+		case *ast.SendStmt, *ast.SelectStmt, *ast.GoStmt, *ast.DeferStmt:
+			effect = true
+		}
+		return !effect
+	})
+	return effect
+}
+
+// isEffectfulCall reports whether call may have an effect. Conversions and
+// pure builtins such as len do not.
+func isEffectfulCall(call *ast.CallExpr, info *types.Info) bool {
+	if tv, ok := info.Types[call.Fun]; ok && tv.IsType() {
+		return false
+	}
+	if id, ok := ast.Unparen(call.Fun).(*ast.Ident); ok {
+		if b, ok := info.Uses[id].(*types.Builtin); ok {
+			switch b.Name() {
+			case "append", "cap", "complex", "imag", "len", "make", "max", "min", "new", "real":
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// writesNonLocal reports whether assigning to lhs may modify state outside
+// the function. Only plain identifiers of local variables are provably local.
+func writesNonLocal(lhs ast.Expr, info *types.Info, pkgScope *types.Scope) bool {
+	if lhs == nil {
+		return false
+	}
+	id, ok := ast.Unparen(lhs).(*ast.Ident)
+	if !ok {
+		return true
+	}
+	if id.Name == "_" {
+		return false
+	}
+	obj := info.ObjectOf(id)
+	return obj == nil || obj.Parent() == pkgScope
+}
@@ -0,0 +1,123 @@
+package unusedfunc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestAnalyzer_FindEmptyInits(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		empty bool
+	}{
+		{name: "empty", body: ``, empty: true},
+		{name: "comments_only", body: `// TODO: register handlers`, empty: true},
+		{name: "dead_branch", body: `if false { counter++ }`, empty: true},
+		{name: "local_only", body: `x := len("abc"); x++; _ = x`, empty: true},
+		{name: "conversion", body: `_ = float64(counter)`, empty: true},
+		{name: "call", body: `setup()`, empty: false},
+		{name: "builtin_with_effect", body: `println("init")`, empty: false},
+		{name: "package_var_assignment", body: `counter = 1`, empty: false},
+		{name: "package_var_increment", body: `counter++`, empty: false},
+		{name: "write_through_pointer", body: `p := &counter; *p = 2`, empty: false},
+		{name: "map_write", body: `registry["a"] = 1`, empty: false},
+		{name: "channel_send", body: `ch <- 1`, empty: false},
+		{name: "channel_receive", body: `<-ch`, empty: false},
+		{name: "endless_loop", body: `for {}`, empty: false},
+		{name: "range_over_slice", body: `for range []int{1, 2} {}`, empty: true},
+		{name: "range_over_channel", body: `for range ch {}`, empty: false},
+		{name: "range_over_func", body: `for range seq {}`, empty: false},
+		{name: "range_over_func_literal", body: `for i := range func(yield func(int) bool) { yield(1) } { _ = i }`, empty: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := `package p
+
+var (
+	counter  int
+	registry = map[string]int{}
+	ch       = make(chan int, 1)
+	seq      = func(yield func() bool) { counter++ }
+)
+
+func setup() {}
+
+func init() {
+	` + tt.body + `
+}
+`
+			pkg := typeCheckTestPackage(t, "example.com/p", src)
+			analyzer := NewAnalyzer(AnalyzerOptions{})
+
+			found := analyzer.findEmptyInits([]*packages.Package{pkg})
+			if !tt.empty {
+				require.Empty(t, found)
+				return
+			}
+			require.Len(t, found, 1)
+			require.Equal(t, "example.com/p.init#p.go:12", found[0].Name)
+			require.Equal(t, EmptyInitReason, found[0].Reason)
+			require.Equal(t, 12, found[0].Position.Line)
+		})
+	}
+}
+
+func TestAnalyzer_FindEmptyInitsSeveralInits(t *testing.T) {
+	const src = `package p
+
+var counter int
+
+func init() {}
+
+func init() {
+	counter = 1
+}
+
+func init() {
+	// Nothing to do yet.
+}
+`
+	pkg := typeCheckTestPackage(t, "example.com/p", src)
+	analyzer := NewAnalyzer(AnalyzerOptions{})
+
+	found := analyzer.findEmptyInits([]*packages.Package{pkg})
+	names := make([]string, 0, len(found))
+	for _, f := range found {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"example.com/p.init#p.go:5", "example.com/p.init#p.go:11"}, names)
+}
+
+// typeCheckTestPackage parses and type-checks a single-file package.
+func typeCheckTestPackage(t *testing.T, pkgPath, src string) *packages.Package {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	tpkg, err := new(types.Config).Check(pkgPath, fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	return &packages.Package{
+		ID:        pkgPath,
+		PkgPath:   pkgPath,
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     tpkg,
+		TypesInfo: info,
+	}
+}
@@ -0,0 +1,107 @@
+package unusedfunc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// estimateLoadMode lists the package graph without parsing or type checking,
+// which is what makes an estimate cheap compared to an analysis.
+const estimateLoadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedImports |
+	packages.NeedDeps
+
+// Rough costs of an analysis, measured on a development machine: every
+// package in the import graph, dependencies included, is type checked and
+// built into SSA, while the call graph grows with the functions of the
+// analyzed packages.
+const (
+	costPerPackage  = 40 * time.Millisecond
+	costPerFunction = 150 * time.Microsecond
+)
+
+// Estimate describes the work an analysis of some packages would do.
+type Estimate struct {
+	// Packages is the number of packages that would be analyzed.
+	Packages int `json:"packages"`
+	// Dependencies is the number of other packages they import, directly or
+	// indirectly, which are loaded but not analyzed.
+	Dependencies int `json:"dependencies"`
+	// Files is the number of Go files in the analyzed packages.
+	Files int `json:"files"`
+	// Functions approximates the number of functions and methods declared in
+	// the analyzed packages, by counting lines that start with "func".
+	Functions int `json:"functions"`
+	// Duration is a rough guess at how long the analysis would take.
+	Duration time.Duration `json:"estimated_duration"`
+}
+
+// EstimateCost lists the packages LoadPackages would load for opts, without
+// parsing or type checking them, and estimates the cost of analyzing them.
+func EstimateCost(ctx context.Context, opts LoaderOptions) (*Estimate, error) {
+	cfg, patterns := loadConfig(ctx, opts, estimateLoadMode)
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages found matching patterns: %v", patterns)
+	}
+
+	var est Estimate
+	roots := deduplicatePackages(pkgs)
+	analyzed := make(map[string]bool, len(roots))
+	for _, pkg := range roots {
+		analyzed[pkg.PkgPath] = true
+		est.Packages++
+		est.Files += len(pkg.GoFiles)
+		for _, filename := range pkg.GoFiles {
+			n, err := countFuncLines(filename, opts.Overlay)
+			if err != nil {
+				return nil, err
+			}
+			est.Functions += n
+		}
+	}
+
+	deps := make(map[string]bool)
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if !analyzed[pkg.PkgPath] {
+			deps[pkg.PkgPath] = true
+		}
+	})
+	est.Dependencies = len(deps)
+
+	est.Duration = time.Duration(est.Packages+est.Dependencies)*costPerPackage +
+		time.Duration(est.Functions)*costPerFunction
+	return &est, nil
+}
+
+// countFuncLines counts the lines of filename, or of its overlay, that start
+// a function declaration.
+func countFuncLines(filename string, overlay map[string][]byte) (int, error) {
+	src, ok := overlay[filename]
+	if !ok {
+		var err error
+		if src, err = os.ReadFile(filename); err != nil {
+			return 0, fmt.Errorf("reading %s: %w", filename, err)
+		}
+	}
+
+	n := 0
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(nil, len(src)+1)
+	for scanner.Scan() {
+		if bytes.HasPrefix(scanner.Bytes(), []byte("func ")) || bytes.HasPrefix(scanner.Bytes(), []byte("func(")) {
+			n++
+		}
+	}
+	return n, scanner.Err()
+}
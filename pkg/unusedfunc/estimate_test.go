@@ -0,0 +1,53 @@
+package unusedfunc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/estimate\n\ngo 1.24\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.go"), []byte(`package lib
+
+type T struct{}
+
+func (T) Method() {}
+
+func helper() {
+	_ = func() {}
+}
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib_test.go"), []byte("package lib\n\nfunc testHelper() { helper() }\n"), 0o600))
+
+	tests := []struct {
+		name      string
+		noTests   bool
+		files     int
+		functions int
+		deps      bool // the generated test main imports testing
+	}{
+		{name: "with_tests", files: 2, functions: 3, deps: true},
+		{name: "without_tests", noTests: true, files: 1, functions: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est, err := EstimateCost(context.Background(), LoaderOptions{
+				Packages: []string{"./..."},
+				Dir:      dir,
+				NoTests:  tt.noTests,
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, est.Packages)
+			require.Equal(t, tt.deps, est.Dependencies > 0)
+			require.Equal(t, tt.files, est.Files)
+			require.Equal(t, tt.functions, est.Functions)
+			require.Positive(t, est.Duration)
+		})
+	}
+}
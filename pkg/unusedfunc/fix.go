@@ -0,0 +1,78 @@
+package unusedfunc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	goanalysis "golang.org/x/tools/go/analysis"
+
+	"github.com/715d/unusedfunc/internal/analysis"
+)
+
+// SuggestedFix returns a fix that deletes the declaration of the unused
+// function fi, including its doc comment, in the form gopls applies as a
+// quick fix. Only unexported functions and methods get a fix, as removing
+// an export may break code outside the analyzed packages; ok is false for
+// them and for functions whose declaration is not in the package syntax.
+func SuggestedFix(fi *analysis.FuncInfo) (fix goanalysis.SuggestedFix, ok bool) {
+	if fi.Object == nil || fi.Object.Exported() || fi.Package == nil || fi.Package.Fset == nil {
+		return goanalysis.SuggestedFix{}, false
+	}
+
+	decl := findFuncDecl(fi.Package.Syntax, fi.Object.Pos())
+	if decl == nil {
+		return goanalysis.SuggestedFix{}, false
+	}
+
+	start := decl.Pos()
+	if decl.Doc != nil {
+		start = decl.Doc.Pos()
+	}
+	end := decl.End()
+	// Take the rest of the last line too, so no blank line is left behind.
+	if file := fi.Package.Fset.File(end); file != nil {
+		if line := file.Line(end); line < file.LineCount() {
+			end = file.LineStart(line + 1)
+		}
+	}
+
+	return goanalysis.SuggestedFix{
+		Message:   fmt.Sprintf("Remove unused function %s", fi.Object.Name()),
+		TextEdits: []goanalysis.TextEdit{{Pos: start, End: end}},
+	}, true
+}
+
+// DeclLines returns the number of source lines the declaration of fi
+// spans, including its doc comment: the lines SuggestedFix would remove.
+// It returns 0 if the declaration is not in the package syntax.
+func DeclLines(fi *analysis.FuncInfo) int {
+	if fi.Object == nil || fi.Package == nil || fi.Package.Fset == nil {
+		return 0
+	}
+	decl := findFuncDecl(fi.Package.Syntax, fi.Object.Pos())
+	if decl == nil {
+		return 0
+	}
+	start := decl.Pos()
+	if decl.Doc != nil {
+		start = decl.Doc.Pos()
+	}
+	fset := fi.Package.Fset
+	return fset.Position(decl.End()).Line - fset.Position(start).Line + 1
+}
+
+// findFuncDecl returns the declaration whose name is at pos.
+func findFuncDecl(files []*ast.File, pos token.Pos) *ast.FuncDecl {
+	for _, file := range files {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+		for _, d := range file.Decls {
+			if fd, ok := d.(*ast.FuncDecl); ok && fd.Name.Pos() == pos {
+				return fd
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package unusedfunc
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/715d/unusedfunc/internal/analysis"
+)
+
+func TestSuggestedFix(t *testing.T) {
+	const src = `package p
+
+// helper is no longer called.
+// It spans two comment lines.
+func helper() int {
+	return 1
+}
+
+func (t T) method() {}
+
+type T struct{}
+
+// Exported is unused too.
+func Exported() {}
+
+func last() {}`
+
+	pkg := typeCheckTestPackage(t, "example.com/p", src)
+	lookup := func(name string) types.Object {
+		if obj := pkg.Types.Scope().Lookup(name); obj != nil {
+			return obj
+		}
+		obj, _, _ := types.LookupFieldOrMethod(pkg.Types.Scope().Lookup("T").Type(), false, pkg.Types, name)
+		return obj
+	}
+
+	tests := []struct {
+		name     string
+		expected string // removed text; empty if no fix is offered
+	}{
+		{
+			name: "helper",
+			expected: "// helper is no longer called.\n// It spans two comment lines.\n" +
+				"func helper() int {\n\treturn 1\n}\n",
+		},
+		{name: "method", expected: "func (t T) method() {}\n"},
+		{name: "Exported"},
+		{name: "last", expected: "func last() {}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fix, ok := SuggestedFix(&analysis.FuncInfo{Object: lookup(tt.name), Package: pkg})
+			if tt.expected == "" {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			require.Len(t, fix.TextEdits, 1)
+
+			edit := fix.TextEdits[0]
+			file := pkg.Fset.File(edit.Pos)
+			require.Empty(t, edit.NewText)
+			require.Equal(t, tt.expected, src[file.Offset(edit.Pos):file.Offset(edit.End)])
+		})
+	}
+}
+
+func TestDeclLines(t *testing.T) {
+	const src = `package p
+
+// helper is no longer called.
+// It spans two comment lines.
+func helper() int {
+	return 1
+}
+
+func oneLine() {}`
+
+	pkg := typeCheckTestPackage(t, "example.com/p", src)
+	lookup := func(name string) types.Object { return pkg.Types.Scope().Lookup(name) }
+
+	require.Equal(t, 5, DeclLines(&analysis.FuncInfo{Object: lookup("helper"), Package: pkg}))
+	require.Equal(t, 1, DeclLines(&analysis.FuncInfo{Object: lookup("oneLine"), Package: pkg}))
+	require.Zero(t, DeclLines(&analysis.FuncInfo{Object: lookup("helper")}))
+}
@@ -4,8 +4,10 @@ package unusedfunc
 import (
 	"context"
 	"fmt"
+	"go/ast"
 	"maps"
 	"slices"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -37,34 +39,27 @@ type LoaderOptions struct {
 	// If empty, uses the current working directory.
 	Dir string
 
-	// Env is the environment to use for loading.
-	// If nil, uses a copy of os.Environ() with CGO_ENABLED=0.
+	// Env is the environment of the go command used for loading, e.g. to
+	// pin GOFLAGS, GOPROXY=off or GOCACHE in a sandbox. If nil, the
+	// environment of the current process is used.
 	Env []string
-}
 
-// LoadPackages loads Go packages with consistent configuration for unusedfunc analysis.
-func LoadPackages(ctx context.Context, opts LoaderOptions) ([]*packages.Package, error) {
-	// Default to current directory patterns.
-	patterns := opts.Packages
-	if len(patterns) == 0 {
-		patterns = []string{"./..."}
-	}
+	// NoTests skips test files. By default test variants of the packages are
+	// loaded too, so functions used only by tests count as used.
+	NoTests bool
 
-	cfg := &packages.Config{
-		Context: ctx,
-		Mode:    defaultLoadMode,
-		Tests:   true, // Always load test files to detect usage from tests
-		Env:     opts.Env,
-	}
-
-	if opts.Dir != "" {
-		cfg.Dir = opts.Dir
-	}
+	// Overlay maps absolute file paths to contents that replace, or add to,
+	// the files on disk. See packages.Config.Overlay.
+	Overlay map[string][]byte
 
-	if len(opts.BuildTags) > 0 {
-		cfg.BuildFlags = append(cfg.BuildFlags, "-tags", strings.Join(opts.BuildTags, ","))
-	}
+	// Logf, if set, receives the debug log of package loading, including
+	// the go command invocations. See packages.Config.Logf.
+	Logf func(format string, args ...any)
+}
 
+// LoadPackages loads Go packages with consistent configuration for unusedfunc analysis.
+func LoadPackages(ctx context.Context, opts LoaderOptions) ([]*packages.Package, error) {
+	cfg, patterns := loadConfig(ctx, opts, defaultLoadMode)
 	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
 		return nil, fmt.Errorf("loading packages: %w", err)
@@ -75,23 +70,134 @@ func LoadPackages(ctx context.Context, opts LoaderOptions) ([]*packages.Package,
 	}
 
 	// Check for errors in loaded packages.
-	var errorMessages []string
+	var errorMessages, unanalyzed []string
+	listed := make(map[string]bool)
 	for _, pkg := range pkgs {
 		if len(pkg.Errors) > 0 {
 			for _, err := range pkg.Errors {
 				errorMsg := fmt.Sprintf("package %s: %v", pkg.PkgPath, err)
 				errorMessages = append(errorMessages, errorMsg)
 			}
+			unanalyzed = append(unanalyzed, unanalyzedFunctions(pkg, listed)...)
 		}
 	}
 
 	if len(errorMessages) > 0 {
+		if len(unanalyzed) > 0 {
+			errorMessages = append(errorMessages, "fix the build errors first; these functions could not be analyzed:")
+			errorMessages = append(errorMessages, unanalyzed...)
+		}
 		return nil, fmt.Errorf("package errors:\n%s", strings.Join(errorMessages, "\n"))
 	}
 
 	return deduplicatePackages(pkgs), nil
 }
 
+// unanalyzedFunctions lists, one line per file, the functions declared in
+// the files of pkg that its errors point to, other than the files in listed,
+// which it adds them to. The type information of such functions may be
+// missing or wrong, so their results could not be trusted.
+func unanalyzedFunctions(pkg *packages.Package, listed map[string]bool) []string {
+	errFiles := make(map[string]bool)
+	for _, err := range pkg.Errors {
+		if file := errorFile(err.Pos); file != "" {
+			errFiles[file] = true
+		}
+	}
+
+	var lines []string
+	for _, file := range pkg.Syntax {
+		filename := pkg.Fset.Position(file.Pos()).Filename
+		if !errFiles[filename] || listed[filename] {
+			continue
+		}
+		listed[filename] = true
+
+		var names []string
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name == nil {
+				continue
+			}
+			name := fn.Name.Name
+			if fn.Recv != nil && len(fn.Recv.List) == 1 {
+				if recv := receiverName(fn.Recv.List[0].Type); recv != "" {
+					name = recv + "." + name
+				}
+			}
+			names = append(names, name)
+		}
+		if len(names) > 0 {
+			lines = append(lines, fmt.Sprintf("  %s: %s", filename, strings.Join(names, ", ")))
+		}
+	}
+	return lines
+}
+
+// errorFile returns the file of the position of a packages.Error, given as
+// "file:line:column", "file:line" or "file", or "" if it has none.
+func errorFile(pos string) string {
+	for range 2 {
+		i := strings.LastIndexByte(pos, ':')
+		if i < 0 {
+			break
+		}
+		if _, err := strconv.Atoi(pos[i+1:]); err != nil {
+			break
+		}
+		pos = pos[:i]
+	}
+	if pos == "-" {
+		return ""
+	}
+	return pos
+}
+
+// receiverName returns the name of the type of a method receiver, such as
+// "T" for *T or T[K].
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.ParenExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// loadConfig returns the packages.Config and patterns that load opts with mode.
+func loadConfig(ctx context.Context, opts LoaderOptions, mode packages.LoadMode) (*packages.Config, []string) {
+	// Default to current directory patterns.
+	patterns := opts.Packages
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    mode,
+		Tests:   !opts.NoTests, // Load test files to detect usage from tests
+		Env:     opts.Env,
+		Overlay: opts.Overlay,
+		Logf:    opts.Logf,
+	}
+
+	if opts.Dir != "" {
+		cfg.Dir = opts.Dir
+	}
+
+	if len(opts.BuildTags) > 0 {
+		cfg.BuildFlags = append(cfg.BuildFlags, "-tags", strings.Join(opts.BuildTags, ","))
+	}
+	return cfg, patterns
+}
+
 // deduplicatePackages removes duplicate packages, preferring test variants over regular packages.
 // Test variants (IDs containing "[...]") are supersets that include all production code plus.
 // test-only exports, so they should be preferred to avoid analyzing the same functions twice.
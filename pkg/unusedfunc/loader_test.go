@@ -1,6 +1,10 @@
 package unusedfunc
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -119,3 +123,125 @@ func TestIsSuperset(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadPackages_Overlay(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/overlay\n\ngo 1.24\n"), 0o600))
+
+	// The source file exists only in the overlay.
+	filename := filepath.Join(dir, "main.go")
+	pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"."},
+		Dir:      dir,
+		Overlay: map[string][]byte{
+			filename: []byte("package main\n\nfunc main() {}\n"),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, "example.com/overlay", pkgs[0].PkgPath)
+	require.Equal(t, []string{filename}, pkgs[0].GoFiles)
+}
+
+func TestLoadPackages_EnvAndLogf(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/hermetic\n\ngo 1.24\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o600))
+
+	var logged []string
+	pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"."},
+		Dir:      dir,
+		Env:      append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off"),
+		NoTests:  true,
+		Logf: func(format string, args ...any) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, "example.com/hermetic", pkgs[0].PkgPath)
+	require.NotEmpty(t, logged)
+}
+
+func TestLoadPackages_NoTests(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/notests\n\ngo 1.24\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.go"), []byte("package lib\n\nfunc helper() {}\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib_test.go"), []byte("package lib\n\nimport \"testing\"\n\nfunc TestHelper(t *testing.T) { helper() }\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		noTests  bool
+		expected []string
+	}{
+		{
+			name:     "with_tests",
+			expected: []string{"lib.go", "lib_test.go"},
+		},
+		{
+			name:     "without_tests",
+			noTests:  true,
+			expected: []string{"lib.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgs, err := LoadPackages(context.Background(), LoaderOptions{
+				Packages: []string{"."},
+				Dir:      dir,
+				NoTests:  tt.noTests,
+			})
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+
+			var files []string
+			for _, f := range pkgs[0].GoFiles {
+				files = append(files, filepath.Base(f))
+			}
+			require.Equal(t, tt.expected, files)
+		})
+	}
+}
+
+func TestLoadPackages_ErrorsListUnanalyzedFunctions(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/broken\n\ngo 1.24\n",
+		"ok.go":  "package broken\n\nfunc Fine() {}\n",
+		"bad.go": "package broken\n\ntype T struct{}\n\nfunc (t *T) Method() {}\n\nfunc Broken() int { return undefined }\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	_, err := LoadPackages(context.Background(), LoaderOptions{
+		Packages: []string{"."},
+		Dir:      dir,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "undefined: undefined")
+	require.Contains(t, err.Error(), "could not be analyzed")
+	require.Contains(t, err.Error(), "bad.go: T.Method, Broken")
+	require.NotContains(t, err.Error(), "Fine")
+}
+
+func TestErrorFile(t *testing.T) {
+	tests := []struct {
+		pos      string
+		expected string
+	}{
+		{pos: "/src/a.go:3:14", expected: "/src/a.go"},
+		{pos: "/src/a.go:3", expected: "/src/a.go"},
+		{pos: "/src/a.go", expected: "/src/a.go"},
+		{pos: `C:\src\a.go:3:14`, expected: `C:\src\a.go`},
+		{pos: "-", expected: ""},
+		{pos: "", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pos, func(t *testing.T) {
+			require.Equal(t, tt.expected, errorFile(tt.pos))
+		})
+	}
+}
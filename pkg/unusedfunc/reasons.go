@@ -0,0 +1,49 @@
+package unusedfunc
+
+// The reasons findings are reported for. They are part of the output that
+// tools filter on, so they do not change between releases; see also
+// docs/reference/reasons.md.
+const (
+	UnexportedReason         = "unexported and unused"
+	UnexportedReceiverReason = "exported method on unexported type and unused"
+	InternalReason           = "exported in internal and unused"
+	TestSupportReason        = "exported in test-support package and unused"
+	UnimportedReason         = "exported in unimported package and unused"
+	MainReason               = "exported in main and unused"
+	UnreferencedTypeReason   = "exported method on unreferenced type and unused"
+	StrictReason             = "exported and unused (strict mode)"
+	BenchmarksReason         = "used only by benchmarks"
+	ExamplesReason           = "used only by examples"
+	AssumedRemovedReason     = "unused once the assumed-removed functions are deleted"
+	ExcludedOnlyReason       = "used only by excluded packages"
+	UnusedTypeReason         = "type and all its methods unused"
+	// EmptyInitReason is the reason reported for init functions without effect.
+	EmptyInitReason = "init has no effect"
+)
+
+// ReasonInfo pairs a reason with a short category naming it, such as the
+// "unexported" of --only-reason.
+type ReasonInfo struct {
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// Reasons returns every reason a finding can be reported for.
+func Reasons() []ReasonInfo {
+	return []ReasonInfo{
+		{"unexported", UnexportedReason},
+		{"unexported-receiver", UnexportedReceiverReason},
+		{"internal", InternalReason},
+		{"test-support", TestSupportReason},
+		{"unimported", UnimportedReason},
+		{"main", MainReason},
+		{"unreferenced-type", UnreferencedTypeReason},
+		{"strict", StrictReason},
+		{"benchmarks", BenchmarksReason},
+		{"examples", ExamplesReason},
+		{"assumed-removed", AssumedRemovedReason},
+		{"excluded-only", ExcludedOnlyReason},
+		{"unused-type", UnusedTypeReason},
+		{"empty-init", EmptyInitReason},
+	}
+}
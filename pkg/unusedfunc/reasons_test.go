@@ -0,0 +1,26 @@
+package unusedfunc
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestReasons checks that categories and reasons are unique and that each
+// reason has its section in the reference documentation.
+func TestReasons(t *testing.T) {
+	doc, err := os.ReadFile("../../docs/reference/reasons.md")
+	require.NoError(t, err)
+
+	categories := make(map[string]bool)
+	reasons := make(map[string]bool)
+	for _, r := range Reasons() {
+		require.False(t, categories[r.Category], "duplicate category %q", r.Category)
+		require.False(t, reasons[r.Reason], "duplicate reason %q", r.Reason)
+		categories[r.Category] = true
+		reasons[r.Reason] = true
+		require.True(t, strings.Contains(string(doc), "\n## "+r.Reason+"\n"), "reason %q not documented", r.Reason)
+	}
+}
@@ -5,9 +5,42 @@ import "go/token"
 
 // UnusedFunction represents a function that should be reported as unused.
 type UnusedFunction struct {
-	Name       string         `json:"name"`
-	Position   token.Position `json:"position"`
-	Reason     string         `json:"reason"`
-	Suppressed bool           `json:"suppressed"`
-	Package    string         `json:"package"`
+	Name        string         `json:"name"`
+	Position    token.Position `json:"position"`
+	Reason      string         `json:"reason"`
+	Suppressed  bool           `json:"suppressed"`
+	Package     string         `json:"package"`
+	PackageName string         `json:"package_name"`
+	Owners      []string       `json:"owners,omitempty"`
+	// Instantiations counts the distinct instantiations of a generic function.
+	Instantiations int `json:"instantiations,omitempty"`
+	// Lines is the number of source lines of the declaration, including its
+	// doc comment; 0 if unknown.
+	Lines int `json:"lines,omitempty"`
+	// Calls names the other reported functions this one calls; only set
+	// when AnalyzerOptions.DeadCalls is.
+	Calls []string `json:"calls,omitempty"`
+	// Receiver is the type a method is declared on, as "path.Type"; empty
+	// for a function. ReceiverMethods counts the methods declared on it, and
+	// ReceiverPos is the position of its declaration.
+	Receiver        string         `json:"receiver,omitempty"`
+	ReceiverMethods int            `json:"receiver_methods,omitempty"`
+	ReceiverPos     token.Position `json:"-"`
+	// Confidence is HighConfidence, or LowConfidence for findings the
+	// analysis is likely to get wrong, which do not fail a run.
+	Confidence string `json:"confidence,omitempty"`
+	// SupersededBy names a used function whose name suggests it replaces
+	// this one, such as FooV2 for Foo; a hint for triage, empty if none.
+	SupersededBy string `json:"superseded_by,omitempty"`
+	// StrictOnly marks, with AnalyzerOptions.Dual, a function that only
+	// strict mode reports: the public API of a library package uses it.
+	StrictOnly bool `json:"strict_only,omitempty"`
 }
+
+// The confidence levels of a finding.
+const (
+	HighConfidence = "high"
+	// LowConfidence is given to findings in packages importing reflect,
+	// whose functions may be called through reflection.
+	LowConfidence = "low"
+)
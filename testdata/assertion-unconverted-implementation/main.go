@@ -0,0 +1,38 @@
+// Package main tests that a type assertion does not make the methods of
+// types that are never converted to an interface reachable.
+package main
+
+type shape interface{ area() int }
+
+type square struct{ side int }
+
+// area is called through shape on a square.
+func (s square) area() int { return s.side * s.side }
+
+type circle struct{ r int }
+
+// area implements shape, but no circle is ever converted to an interface,
+// so no call through shape can reach it.
+func (c circle) area() int { return 3 * c.r * c.r }
+
+type namer interface{ name() string }
+
+type label struct{ text string }
+
+// name is called after asserting an any to namer. The assertion scans the
+// program for implementations of namer, which must not add circle to the
+// implementations of shape.
+func (l label) name() string { return l.text }
+
+func describe(v any) {
+	if n, ok := v.(namer); ok {
+		println(n.name())
+	}
+}
+
+func main() {
+	var s shape = square{side: 2}
+	println(s.area())
+	describe(label{text: "square"})
+	_ = circle{r: 1}
+}
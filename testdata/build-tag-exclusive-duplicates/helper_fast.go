@@ -0,0 +1,8 @@
+//go:build fast
+
+package main
+
+func mode() string { return "fast" }
+
+// helper is the fast variant. It is never called.
+func helper() int { return 1 }
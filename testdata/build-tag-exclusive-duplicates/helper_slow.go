@@ -0,0 +1,8 @@
+//go:build !fast
+
+package main
+
+func mode() string { return "slow" }
+
+// helper is the default variant. It is never called.
+func helper() int { return 2 }
@@ -0,0 +1,8 @@
+// Package main declares helper twice, in files with mutually exclusive build
+// constraints. Only the declaration in the active build exists, and since
+// neither is called it must be reported exactly once, from the right file.
+package main
+
+func main() {
+	println(mode())
+}
@@ -0,0 +1,45 @@
+// Package shapes tests methods of exported library types that nothing in
+// the analyzed code refers to.
+package shapes
+
+// Circle is returned by NewCircle, so its methods are library API (USED).
+type Circle struct {
+	r float64
+}
+
+// NewCircle returns a circle of radius r.
+func NewCircle(r float64) *Circle { return &Circle{r: r} }
+
+// Area is an exported method of a referenced type (USED).
+func (c *Circle) Area() float64 { return c.r * c.r * pi() }
+
+func pi() float64 { return 3.14159 }
+
+// Polygon is never referenced outside its own declarations: no function
+// returns it, no other type embeds it and no code constructs it.
+type Polygon struct {
+	sides []float64
+}
+
+// Perimeter is kept as library API by default, but reported with
+// cascade_types (UNUSED when cascading).
+func (p *Polygon) Perimeter() float64 { return sum(p.sides) }
+
+// Scale returns a scaled copy, referring to Polygon only from its own
+// method (UNUSED when cascading).
+func (p *Polygon) Scale(f float64) *Polygon {
+	sides := make([]float64, len(p.sides))
+	for i, s := range p.sides {
+		sides[i] = s * f
+	}
+	return &Polygon{sides: sides}
+}
+
+// sum is only called by Polygon.Perimeter (UNUSED when cascading).
+func sum(xs []float64) float64 {
+	total := 0.0
+	for _, x := range xs {
+		total += x
+	}
+	return total
+}
@@ -0,0 +1,27 @@
+// Package main exposes functions to the linker with //go:cgo_export_dynamic
+// and //go:cgo_export_static. Like //export, these make a function callable
+// from outside Go, so it is an entry point even without Go callers. The
+// pragmas name the function they export and may appear anywhere in the file.
+package main
+
+//go:cgo_export_dynamic dynamicCallback
+//go:cgo_export_static main.staticCallback
+
+// dynamicCallback is exported to the dynamic linker.
+func dynamicCallback() int {
+	return helperForCallback()
+}
+
+// staticCallback is exported to the static linker under a qualified name.
+func staticCallback() {}
+
+// helperForCallback is only reachable through dynamicCallback.
+func helperForCallback() int { return 1 }
+
+// notExported is never referenced by a pragma and never called.
+func notExported() {}
+
+// staticCallbackHelper shares a prefix with an exported name but is unused.
+func staticCallbackHelper() {}
+
+func main() {}
@@ -0,0 +1,57 @@
+// Package main sends concrete values on a chan interface{} and recovers them
+// with type assertions on the receiving side.
+package main
+
+type runner interface {
+	run()
+}
+
+// job is converted to interface{} when sent on jobs, so it is a runtime
+// type and the invoke of run through runner reaches job.run (USED).
+type job struct{ id int }
+
+func (j job) run() { println("job", j.id) }
+
+// stop is only reachable through the assertion to job (UNUSED - should be
+// reported).
+func (j job) stop() { println("stop", j.id) }
+
+// result is asserted to its concrete type on receive, so describe is called
+// statically (USED).
+type result struct{ ok bool }
+
+func (r *result) describe() { println("result", r.ok) }
+
+func (r *result) run() { println("result run") }
+
+// idle implements runner but is never sent on a channel or otherwise
+// converted to an interface. The assertion to runner in user code keeps the
+// runner methods of every implementation, so run is kept all the same
+// (USED), but reset is not (UNUSED - should be reported).
+type idle struct{}
+
+func (idle) run() { println("idle") }
+
+func (idle) reset() { println("reset") }
+
+func worker(in <-chan interface{}, done chan<- struct{}) {
+	for v := range in {
+		if r, ok := v.(runner); ok {
+			r.run()
+		}
+		if res, ok := v.(*result); ok {
+			res.describe()
+		}
+	}
+	close(done)
+}
+
+func main() {
+	in := make(chan interface{}, 2)
+	done := make(chan struct{})
+	go worker(in, done)
+	in <- job{id: 1}
+	in <- &result{ok: true}
+	close(in)
+	<-done
+}
@@ -0,0 +1,41 @@
+package main
+
+// Sink is asserted below but otherwise unused: no value is ever stored in a
+// Sink and its method is never called through it.
+type Sink interface {
+	Write(p []byte) int
+}
+
+// fileSink is never created.
+type fileSink struct{ path string }
+
+// The assertion checks at compile time that *fileSink implements Sink. It
+// is not a conversion at run time, and the SSA builder drops it.
+var _ Sink = (*fileSink)(nil)
+
+// Write is required by Sink: only the assertion refers to it.
+func (f *fileSink) Write(p []byte) int {
+	f.sync()
+	return len(p)
+}
+
+// sync is called by Write, and so lives or dies with it.
+func (f *fileSink) sync() {
+	println("sync", f.path)
+}
+
+// rotate is not required by Sink: the assertion never keeps it.
+func (f *fileSink) rotate() {}
+
+// Buffer is converted to an interface at run time.
+type Buffer struct{ n int }
+
+// Len is used through Lener.
+func (b *Buffer) Len() int { return b.n }
+
+type Lener interface{ Len() int }
+
+func main() {
+	var l Lener = &Buffer{n: 1}
+	println(l.Len())
+}
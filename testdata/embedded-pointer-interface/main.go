@@ -0,0 +1,73 @@
+// Package main tests interfaces satisfied by methods promoted through an
+// embedded pointer: the methods of Outer come from *Inner.
+package main
+
+type reader interface{ Read() int }
+
+type closer interface{ Close() }
+
+type resetter interface{ Reset() }
+
+// Inner provides the methods that Outer promotes.
+type Inner struct{ n int }
+
+// Read is called through reader on an Outer.
+func (i *Inner) Read() int { return i.n }
+
+// Close is called through closer, asserted from a reader holding an Outer.
+func (i *Inner) Close() { i.n = 0 }
+
+// Reset is promoted to Outer, which satisfies resetter, but no Outer is
+// ever used as a resetter.
+func (i *Inner) Reset() { i.n = -1 }
+
+// size is promoted to Outer but never called.
+func (i *Inner) size() int { return i.n }
+
+// Outer satisfies reader, closer and resetter through the embedded *Inner.
+type Outer struct {
+	*Inner
+}
+
+// Shadowed is the only method declared on Outer itself, and is unused.
+func (o Outer) Shadowed() int { return 0 }
+
+type sizer interface{ Size() int }
+
+// Meter provides Size, which Gauge promotes.
+type Meter struct{ n int }
+
+// Size is kept through Gauge, which is only ever asserted from a sizer.
+func (m *Meter) Size() int { return m.n }
+
+// Unit is promoted to Gauge but never called.
+func (m *Meter) Unit() string { return "bytes" }
+
+// Gauge is never converted to sizer in this package. Asserting a sizer to
+// Gauge proves that some code does, so the methods sizer requires are kept.
+type Gauge struct {
+	*Meter
+}
+
+type fixed int
+
+func (f fixed) Size() int { return int(f) }
+
+func measure(s sizer) int {
+	if g, ok := s.(Gauge); ok {
+		return g.n
+	}
+	return s.Size()
+}
+
+func use(r reader) {
+	println(r.Read())
+	if c, ok := r.(closer); ok {
+		c.Close()
+	}
+}
+
+func main() {
+	use(Outer{Inner: &Inner{n: 1}})
+	println(measure(fixed(2)))
+}
@@ -0,0 +1,48 @@
+// Package registry exposes its event handlers as exported data. Code outside
+// the analyzed packages may read Registry and call its fields, so the
+// functions it refers to are kept even though no analyzed code calls them.
+package registry
+
+// Handlers groups the callbacks of the registry.
+type Handlers struct {
+	OnEvent func(string)
+	OnClose func()
+}
+
+// Registry is part of the package API. Its OnEvent field is never called
+// here, but importers can, so handleEvent and closeAll are kept (USED).
+var Registry = Handlers{
+	OnEvent: handleEvent,
+	OnClose: closeAll,
+}
+
+func handleEvent(name string) { println("event", name) }
+
+func closeAll() { println("close") }
+
+// Default, Hooks and Commands hold functions through a pointer, a slice and
+// a map; importers can reach them all the same (USED).
+var Default = &Handlers{OnClose: shutdown}
+
+var Hooks = []func(){flushHooks}
+
+var Commands = map[string]func(){
+	"reset": resetAll,
+}
+
+func shutdown() { println("shutdown") }
+
+func flushHooks() { println("flush") }
+
+func resetAll() { println("reset") }
+
+// fallbacks is unexported and never read, so nothing can call the function
+// it holds (UNUSED - should be reported).
+var fallbacks = Handlers{
+	OnEvent: logEvent,
+}
+
+func logEvent(name string) { println("log", name) }
+
+// unusedHelper is not referenced at all (UNUSED - should be reported).
+func unusedHelper() {}
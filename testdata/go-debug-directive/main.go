@@ -0,0 +1,38 @@
+//go:debug panicnil=1
+
+// Package main uses //go:debug settings, which change the default GODEBUG
+// values of the program but keep no function alive.
+package main
+
+// legacyPanic used to be called when panicnil was set. The //go:debug
+// directive above does not reach it.
+func legacyPanic() {
+	panic(nil)
+}
+
+// misplacedDebug carries a //go:debug directive in its doc comment, where
+// the go command ignores it and vet reports it as misplaced.
+//
+//go:debug httpmuxgo121=1
+func misplacedDebug() {
+	println("misplaced")
+}
+
+// generated is only mentioned by a //go:generate directive, which runs a
+// command at generate time and does not call the function.
+//
+//go:generate go run ./gen -func generated
+func generated() {
+	println("generated")
+}
+
+// pinned has a real runtime directive and is kept for the linker.
+//
+//go:noinline
+func pinned() {
+	println("pinned")
+}
+
+func main() {
+	println("debug")
+}
@@ -0,0 +1,9 @@
+// Package main tests registries filled by package-level map literals and
+// inits whose effects do not reach main. Every init is a root, so whatever
+// an init calls is kept; functions only held by data nobody calls through
+// are reported.
+package main
+
+func main() {
+	println(cacheWarm, formatterCount)
+}
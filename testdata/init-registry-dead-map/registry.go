@@ -0,0 +1,49 @@
+package main
+
+// formatters is read by an init that only counts it. Its values are never
+// called, so the functions it refers to are dead.
+var formatters = map[string]func(string) string{
+	"upper": upperFormat,
+	"lower": lowerFormat,
+}
+
+var formatterCount int
+
+func init() {
+	formatterCount = len(formatters)
+}
+
+// upperFormat and lowerFormat are only stored in formatters (UNUSED - should
+// be reported).
+func upperFormat(s string) string { return s + "!" }
+
+func lowerFormat(s string) string { return s + "." }
+
+// startup is ranged over and called by an init, so warmCache runs (USED).
+var startup = []func(){warmCache}
+
+func init() {
+	for _, f := range startup {
+		f()
+	}
+}
+
+func warmCache() { cacheWarm = true }
+
+var cacheWarm bool
+
+// legacy is never read. flushLegacy has the same signature as warmCache,
+// which is called dynamically, but nothing can load it from legacy (UNUSED -
+// should be reported).
+var legacy = map[string]func(){
+	"flush": flushLegacy,
+}
+
+func flushLegacy() { cacheWarm = false }
+
+// registerDefaults is called directly by an init (USED).
+func init() {
+	registerDefaults()
+}
+
+func registerDefaults() { formatterCount++ }
@@ -0,0 +1,13 @@
+//go:build integration
+
+package store
+
+import "testing"
+
+func TestStoreIntegration(t *testing.T) {
+	s := New()
+	seedFixtures(s)
+	if len(s.values) != 2 {
+		t.Fatalf("got %d values, want 2", len(s.values))
+	}
+}
@@ -0,0 +1,54 @@
+// Package greeting defines interfaces with unexported methods that are only
+// ever satisfied through values stashed behind an empty interface.
+package greeting
+
+import "context"
+
+// Greeter greets in a particular language.
+type Greeter interface {
+	Greet() string
+	language() string
+}
+
+// Namer names a locale.
+type Namer interface {
+	Name() string
+	locale() string
+}
+
+type ctxKey struct{}
+
+// Key is the context key under which a Greeter is stored.
+var Key = ctxKey{}
+
+// English is stored in a context as an untyped value.
+type English struct{}
+
+// Greet is called through the Greeter interface.
+func (English) Greet() string { return "hello" }
+
+// language is required by the type assertion in package handler.
+func (English) language() string { return "en" }
+
+// unusedEnglishHelper is never called.
+func (English) unusedEnglishHelper() string { return "unused" }
+
+// French is stored in a sync.Map as an untyped value.
+type French struct{}
+
+// NewFrench returns a French greeter.
+func NewFrench() any { return French{} }
+
+// Name is called through the Namer interface.
+func (French) Name() string { return "français" }
+
+// locale is required by the type assertion in package handler.
+func (French) locale() string { return "fr" }
+
+// WithEnglish stores an English greeter without converting it to Greeter first.
+func WithEnglish(ctx context.Context) context.Context {
+	return context.WithValue(ctx, Key, English{})
+}
+
+// unusedGreetingHelper is never called.
+func unusedGreetingHelper() {}
@@ -0,0 +1,30 @@
+// Package handler retrieves Greeters stored by other packages.
+package handler
+
+import (
+	"context"
+
+	"example.com/project/greeting"
+	"example.com/project/registry"
+)
+
+// Handle asserts the context value back to a Greeter.
+func Handle(ctx context.Context) string {
+	g, ok := ctx.Value(greeting.Key).(greeting.Greeter)
+	if !ok {
+		return ""
+	}
+	return g.Greet()
+}
+
+// Lookup asserts a registry entry back to a Namer.
+func Lookup(name string) string {
+	v, ok := registry.Load(name)
+	if !ok {
+		return ""
+	}
+	if n, ok := v.(greeting.Namer); ok {
+		return n.Name()
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+// Package main stores interface implementations in a context.Context and a
+// sync.Map. The values are retrieved and type-asserted back to the interface
+// in other packages, so the only evidence that the concrete types satisfy the
+// interface is the TypeAssert instruction.
+package main
+
+import (
+	"context"
+
+	"example.com/project/greeting"
+	"example.com/project/handler"
+	"example.com/project/registry"
+)
+
+func main() {
+	ctx := greeting.WithEnglish(context.Background())
+	println(handler.Handle(ctx))
+
+	registry.Store("fr", greeting.NewFrench())
+	println(handler.Lookup("fr"))
+}
@@ -0,0 +1,16 @@
+// Package registry is a process-wide sync.Map of named values.
+package registry
+
+import "sync"
+
+var entries sync.Map
+
+// Store records v under name.
+func Store(name string, v any) {
+	entries.Store(name, v)
+}
+
+// Load returns the value stored under name.
+func Load(name string) (any, bool) {
+	return entries.Load(name)
+}
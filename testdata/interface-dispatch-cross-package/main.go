@@ -1,6 +1,7 @@
 // Package main demonstrates interface dispatch across package boundaries
 // This reproduces the pattern where SingleConnPool and StickyConnPool
-// show non-deterministic behavior in the unusedfunc analyzer.
+// used to be reported inconsistently between runs, because the RTA
+// "implements" relation depended on the order types were discovered.
 package main
 
 import (
@@ -0,0 +1,7 @@
+// Package runtime is a minimal stand-in for k8s.io/apimachinery/pkg/runtime.
+package runtime
+
+// Object is implemented by all API types registered with a scheme.
+type Object interface {
+	DeepCopyObject() Object
+}
@@ -0,0 +1,7 @@
+module k8s_deepcopy
+
+go 1.21
+
+require k8s.io/apimachinery v0.0.0
+
+replace k8s.io/apimachinery => ./apimachinery
@@ -0,0 +1,23 @@
+// Package v1 holds API types in the style of a Kubernetes API group.
+package v1
+
+// Widget is an API object.
+type Widget struct {
+	Name   string
+	Labels map[string]string
+}
+
+// WidgetList is a list of widgets.
+type WidgetList struct {
+	Items []Widget
+}
+
+// Describe is never called (UNUSED - should be reported in both configurations).
+func (w *Widget) Describe() string {
+	return "widget " + w.Name
+}
+
+// validate is never called (UNUSED - should be reported in both configurations).
+func (w *Widget) validate() bool {
+	return w.Name != ""
+}
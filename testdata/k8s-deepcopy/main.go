@@ -0,0 +1,11 @@
+// Package main tests the generated DeepCopy methods of Kubernetes API types,
+// which the API machinery calls through runtime.Object and scheme
+// registration that the analyzed program does not contain.
+package main
+
+import v1 "k8s_deepcopy/internal/apis/v1"
+
+func main() {
+	list := v1.WidgetList{Items: []v1.Widget{{Name: "a"}}}
+	println(len(list.Items))
+}
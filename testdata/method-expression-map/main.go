@@ -0,0 +1,52 @@
+// Package main tests dispatch tables built from method expressions stored
+// in package-level maps.
+package main
+
+import "os"
+
+// Machine is operated through dispatch tables of method expressions.
+type Machine struct {
+	count int
+}
+
+func (m *Machine) start() { m.count++ }
+
+func (m *Machine) stop() { m.count-- }
+
+// ops is indexed by main, so the methods it holds are called (USED).
+var ops = map[string]func(*Machine){
+	"start": (*Machine).start,
+	"stop":  (*Machine).stop,
+}
+
+func (m *Machine) reset() { m.count = 0 }
+
+// cleanup is ranged over by main, so the methods it holds are called (USED).
+var cleanup = map[string]func(*Machine){
+	"reset": (*Machine).reset,
+}
+
+func (m *Machine) pause() { m.count *= 2 }
+
+func (m *Machine) resume() { m.count /= 2 }
+
+// legacyOps is never read. Its method expressions are evaluated during
+// package initialization, but the methods are never called (UNUSED - should
+// be reported).
+var legacyOps = map[string]func(*Machine){
+	"pause":  (*Machine).pause,
+	"resume": (*Machine).resume,
+}
+
+func main() {
+	m := &Machine{}
+	for _, name := range os.Args[1:] {
+		if op, ok := ops[name]; ok {
+			op(m)
+		}
+	}
+	for _, op := range cleanup {
+		op(m)
+	}
+	println(m.count)
+}
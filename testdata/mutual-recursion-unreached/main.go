@@ -0,0 +1,53 @@
+// Package main contains functions that only call each other. A cluster that
+// nobody outside it reaches is dead as a whole, even though every member has
+// a caller; only the cluster reached from main is used.
+package main
+
+// isEven and isOdd are mutually recursive and reached from main.
+func isEven(n int) bool {
+	if n == 0 {
+		return true
+	}
+	return isOdd(n - 1)
+}
+
+func isOdd(n int) bool {
+	if n == 0 {
+		return false
+	}
+	return isEven(n - 1)
+}
+
+// funcA and funcB only call each other. Nothing else calls either.
+func funcA(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return funcB(n-1) + 1
+}
+
+func funcB(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return funcA(n-1) + 2
+}
+
+// walker methods form a three-member cycle that is never entered.
+type walker struct{ depth int }
+
+func (w *walker) first() {
+	if w.depth > 0 {
+		w.depth--
+		w.second()
+	}
+}
+
+func (w *walker) second() { w.third() }
+
+func (w *walker) third() { w.first() }
+
+func main() {
+	_ = walker{}
+	println(isEven(10))
+}
@@ -0,0 +1,42 @@
+package main
+
+// formatName is initialized by an IIFE, but nothing ever reads it. The
+// closure it holds is never called, so neither are the functions it calls.
+var formatName = func() func(input string) string {
+	prefix := "name_"
+
+	return func(input string) string {
+		result := normalizeName(input)
+		result = decorateName(result)
+		return prefix + result
+	}
+}()
+
+// normalizeName and decorateName are only called from the closure held by
+// the unused formatName and should be reported.
+func normalizeName(input string) string {
+	return "normalized_" + input
+}
+
+func decorateName(input string) string {
+	return "decorated_" + input
+}
+
+// countName is initialized by an IIFE whose body, unlike the closure it
+// returns, runs during package initialization.
+var countName = func() func() int {
+	n := initialCount()
+	return func() int {
+		n++
+		return n
+	}
+}()
+
+// initialCount is called by the IIFE itself and is therefore used.
+func initialCount() int {
+	return 0
+}
+
+func main() {
+	println("started")
+}
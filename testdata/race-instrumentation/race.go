@@ -0,0 +1,21 @@
+//go:build race
+
+package main
+
+import "unsafe"
+
+const raceEnabled = true
+
+// raceAcquire and raceRelease stand in for the annotations a project adds
+// around hand-rolled synchronization when the race detector is active.
+func raceAcquire(addr any) { annotate("acquire", addr) }
+
+func raceRelease(addr any) { annotate("release", addr) }
+
+func annotate(op string, addr any) {
+	_ = op
+	_ = unsafe.Pointer(&addr)
+}
+
+// raceOnlyUnused exists only in race builds and is never called.
+func raceOnlyUnused() {}
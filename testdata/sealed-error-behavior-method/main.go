@@ -0,0 +1,73 @@
+// Package main tests sealed error types whose behavior methods are only
+// reached through the sealed interface after errors.As.
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// DomainError is sealed by the unexported sealed method. Besides the marker
+// it has a behavior method, Code, that callers reach only via the interface.
+type DomainError interface {
+	error
+	sealed()
+	Code() int
+}
+
+// NotFoundError reports a missing resource.
+type NotFoundError struct {
+	resource string
+}
+
+// sealed implements DomainError (USED for interface satisfaction - should NOT be reported).
+func (NotFoundError) sealed() {}
+
+// Error implements error.
+func (e NotFoundError) Error() string { return "not found: " + e.resource }
+
+// Code is called only through DomainError (USED - should NOT be reported).
+func (NotFoundError) Code() int { return 404 }
+
+// describe is never called (UNUSED - should be reported).
+func (e NotFoundError) describe() string { return "missing " + e.resource }
+
+// ConflictError reports a concurrent modification.
+type ConflictError struct{}
+
+// sealed implements DomainError (USED for interface satisfaction - should NOT be reported).
+func (*ConflictError) sealed() {}
+
+// Error implements error.
+func (*ConflictError) Error() string { return "conflict" }
+
+// Code is called only through DomainError (USED - should NOT be reported).
+func (*ConflictError) Code() int { return 409 }
+
+func find(name string) error {
+	if name == "" {
+		return &ConflictError{}
+	}
+	return NotFoundError{resource: name}
+}
+
+// statusOf maps an error to a status code. The concrete error types are only
+// ever seen through errors.As into the sealed interface.
+func statusOf(err error) int {
+	var de DomainError
+	if errors.As(err, &de) {
+		return de.Code()
+	}
+	return 500
+}
+
+// unusedHelper is never called (UNUSED - should be reported).
+func unusedHelper() int { return 0 }
+
+func main() {
+	name := ""
+	if len(os.Args) > 1 {
+		name = os.Args[1]
+	}
+	println(statusOf(find(name)))
+}
@@ -0,0 +1,62 @@
+// Package main contrasts types whose sort.Interface and heap.Interface methods
+// are invoked through interface dispatch with a type whose methods are left
+// behind after switching to sort.Slice, which takes a closure instead.
+package main
+
+import (
+	"container/heap"
+	"sort"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+// byAge is passed to sort.Sort, which calls Len, Less and Swap through
+// sort.Interface.
+type byAge []person
+
+func (a byAge) Len() int           { return len(a) }
+func (a byAge) Less(i, j int) bool { return a[i].age < a[j].age }
+func (a byAge) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// byName implements sort.Interface, but the code sorting by name uses
+// sort.Slice on a plain []person, so these methods are never called.
+type byName []person
+
+func (a byName) Len() int           { return len(a) }
+func (a byName) Less(i, j int) bool { return a[i].name < a[j].name }
+func (a byName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+
+// intHeap is passed to heap.Init and heap.Push, which call all five
+// heap.Interface methods through the interface.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+
+func (h *intHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+func main() {
+	people := []person{{"bob", 31}, {"alice", 42}}
+
+	sort.Sort(byAge(people))
+	println(people[0].name)
+
+	sort.Slice(people, func(i, j int) bool { return people[i].name < people[j].name })
+	println(people[0].name)
+
+	h := &intHeap{5, 2, 8}
+	heap.Init(h)
+	heap.Push(h, 3)
+	println(heap.Pop(h).(int))
+}
@@ -0,0 +1,18 @@
+package main
+
+// The file-wide directive in plugins.go does not reach this file.
+
+// run is used.
+func run() int { return 1 }
+
+// migrate is not suppressed.
+func migrate() {}
+
+// legacyHook is suppressed by its own directive.
+//
+//nolint:unusedfunc
+func legacyHook() {}
+
+func main() {
+	println(run())
+}
@@ -0,0 +1,19 @@
+//lint:file-ignore unusedfunc entry points are looked up by name by the plugin host
+
+package main
+
+// Plugin is a unit the host loads.
+type Plugin struct{ name string }
+
+// initPlugin is suppressed by the file-wide directive.
+func initPlugin() *Plugin { return &Plugin{name: "default"} }
+
+// teardown is suppressed by the file-wide directive.
+func (p *Plugin) teardown() {
+	println("teardown", p.name)
+}
+
+// reload has its own directive, whose reason takes precedence.
+//
+//lint:ignore unusedfunc triggered by SIGHUP in the host
+func (p *Plugin) reload() {}
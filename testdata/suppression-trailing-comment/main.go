@@ -0,0 +1,39 @@
+package main
+
+type Parser struct{}
+
+// Parse is used.
+func (p *Parser) Parse() int { return 1 }
+
+// skip is suppressed by a trailing comment on its signature line.
+func (p *Parser) skip() { //unusedfunc:ignore kept for the next grammar
+	println("skip")
+}
+
+// reset is suppressed by a directive in its doc comment.
+//
+//unusedfunc:ignore
+func (p *Parser) reset() {}
+
+// rewind is suppressed by a nolint directive on the first line of its
+// multi-line signature.
+func (p *Parser) rewind( //nolint:unusedfunc
+	n int,
+) {
+	println(n)
+}
+
+// peek is not suppressed: the directive belongs to a statement in its body.
+func (p *Parser) peek() int {
+	return 0 //unusedfunc:ignore
+}
+
+// backtrack follows peek and is not suppressed either.
+func backtrack() {}
+
+func oneLiner() {} //unusedfunc:ignore
+
+func main() {
+	p := &Parser{}
+	println(p.Parse())
+}
@@ -0,0 +1,21 @@
+// Package main tests helpers that tests call only from closures passed to
+// t.Run and t.Cleanup.
+package main
+
+func main() {
+	println(parse("a"))
+}
+
+func parse(s string) int { return len(s) }
+
+// newFixture is called only inside a t.Run subtest closure (USED).
+func newFixture() []string { return []string{"a", "bb"} }
+
+// checkLength is called only inside a t.Run nested in another t.Run (USED).
+func checkLength(s string, n int) bool { return parse(s) == n }
+
+// removeFixture is called only from a t.Cleanup closure (USED).
+func removeFixture(fixture []string) { _ = fixture[:0] }
+
+// unusedHelper is never called, not even by tests (UNUSED - should be reported).
+func unusedHelper() string { return "unused" }
@@ -0,0 +1,18 @@
+package main
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	t.Run("fixtures", func(t *testing.T) {
+		fixture := newFixture()
+		t.Cleanup(func() { removeFixture(fixture) })
+
+		for _, s := range fixture {
+			t.Run(s, func(t *testing.T) {
+				if !checkLength(s, len(s)) {
+					t.Errorf("parse(%q) != %d", s, len(s))
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,42 @@
+// Package main has two types with the method an unnamed interface requires,
+// only one of which is ever passed where that interface is expected.
+package main
+
+// Conn is passed to process as interface{ NetConn() int }.
+type Conn struct{ fd int }
+
+// NetConn is called through the unnamed interface of process.
+func (c *Conn) NetConn() int {
+	return c.fd
+}
+
+// Pool matches interface{ NetConn() int } too, but is only ever converted
+// to closer.
+type Pool struct{ size int }
+
+// NetConn is kept by default: Pool is a runtime type implementing the
+// unnamed interface of process. In precise mode it is reported, as Pool is
+// never converted to that interface.
+func (p *Pool) NetConn() int {
+	return p.size
+}
+
+// Close is called through closer.
+func (p *Pool) Close() {
+	p.size = 0
+}
+
+type closer interface{ Close() }
+
+func process(conn interface{ NetConn() int }) {
+	println(conn.NetConn())
+}
+
+func shutdown(c closer) {
+	c.Close()
+}
+
+func main() {
+	process(&Conn{fd: 3})
+	shutdown(&Pool{size: 1})
+}
@@ -0,0 +1,42 @@
+// Package main declares package-level slices whose element type is an
+// unnamed interface. Declaring such a store requires nothing of any concrete
+// type: only values actually stored in it and called through it make the
+// interface methods reachable.
+package main
+
+// resource is created and used directly, but never stored in resourceStore.
+type resource struct{ id int }
+
+// ID is called directly from main.
+func (r *resource) ID() int { return r.id }
+
+// Close and Reset match the element interface of resourceStore, which is
+// never appended to or iterated, so nothing can call them.
+func (r *resource) Close() error { return nil }
+
+func (r *resource) Reset() { r.id = 0 }
+
+var resourceStore = []interface {
+	Close() error
+	Reset()
+}{}
+
+// buffer is stored in liveStore, which is iterated, so Flush is reachable.
+type buffer struct{ n int }
+
+func (b *buffer) Flush() { b.n = 0 }
+
+// Discard matches no interface that is used and is never called.
+func (b *buffer) Discard() { b.n = -1 }
+
+var liveStore []interface{ Flush() }
+
+func main() {
+	r := &resource{id: 1}
+	println(r.ID())
+
+	liveStore = append(liveStore, &buffer{})
+	for _, s := range liveStore {
+		s.Flush()
+	}
+}
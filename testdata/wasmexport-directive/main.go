@@ -0,0 +1,41 @@
+//go:build wasm
+
+package main
+
+func main() {}
+
+// add is called by the host of the WebAssembly module.
+//
+//go:wasmexport add
+func add(a, b int32) int32 {
+	return clamp(a + b)
+}
+
+// clamp is only called by an exported function.
+func clamp(v int32) int32 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+// Reset is exported to the host under another name.
+//
+//go:wasmexport reset_state
+func Reset() {
+	state = 0
+}
+
+var state int32
+
+// notExported has a space after the slashes, so it is a plain comment.
+//
+// go:wasmexport not_exported
+func notExported() int32 {
+	return state
+}
+
+// unusedHelper is called by neither the host nor the module.
+func unusedHelper() int32 {
+	return 42
+}
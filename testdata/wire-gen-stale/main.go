@@ -0,0 +1,26 @@
+package main
+
+// Config is provided to the application by the injector.
+type Config struct{ addr string }
+
+// App is built by initializeApp in wire_gen.go.
+type App struct {
+	cfg   *Config
+	store *store
+}
+
+func (a *App) run() {
+	println("listening on", a.cfg.addr)
+	a.store.open()
+}
+
+type store struct{ dsn string }
+
+func (s *store) open() { println("open", s.dsn) }
+
+// newLegacyStore was a provider until the provider set stopped using it.
+func newLegacyStore() *store { return &store{dsn: "legacy"} }
+
+func main() {
+	initializeApp().run()
+}